@@ -0,0 +1,468 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command readygo-config reads, edits and lints config files
+// through the adapters of github.com/Tobecoder/readygo/config.
+//
+// Usage:
+//
+//	readygo-config <command> [-adapter name] [-charset name] [-json] args...
+//
+// The adapter is detected by the file extension and content unless -adapter is given,
+// file "-" reads from stdin, and the template like app.ini.tmpl is rendered before reading.
+// Ini files are written back in their charset, which is detected by bom unless -charset is given.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/Tobecoder/readygo/config"
+)
+
+const usage = `usage: readygo-config <command> [-adapter name] [-charset name] [-json] args...
+
+commands:
+  get      <file> <key>          print the value of key
+  set      <file> <key> <value>  write the value of key into file
+  del      <file> <key>          delete key from file
+  sections <file>                list sections
+  keys     <file> [section]      list keys of all sections or one section
+  validate <file>...             check files can be parsed
+  diff     <file> <file>         list keys added, removed or changed
+  convert  <file> <adapter>      print file in the format of adapter
+  fmt      <file>                rewrite file in canonical format
+  encrypt  <file> <key>...       encrypt values of keys in place by the secret key
+  genkey                         print a new random secret key
+`
+
+// errUsage reports the command is called with wrong arguments
+var errUsage = errors.New("wrong arguments")
+
+// command is the state shared by subcommands
+type command struct {
+	adapter string
+	charset string
+	json    bool
+	stdin   io.Reader
+	stdout  io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	handlers := map[string]func(*command, []string) error{
+		"get":      (*command).get,
+		"set":      (*command).set,
+		"del":      (*command).del,
+		"sections": (*command).sections,
+		"keys":     (*command).keys,
+		"validate": (*command).validate,
+		"diff":     (*command).diff,
+		"convert":  (*command).convert,
+		"fmt":      (*command).format,
+		"encrypt":  (*command).encrypt,
+		"genkey":   (*command).genkey,
+	}
+	handler, ok := handlers[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "readygo-config: unknown command %s\n%s", args[0], usage)
+		return 2
+	}
+
+	cmd := &command{stdin: stdin, stdout: stdout}
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&cmd.adapter, "adapter", "", "config adapter, detected by file extension when empty")
+	fs.StringVar(&cmd.charset, "charset", "", "charset of ini file like gbk, detected by bom when empty")
+	fs.BoolVar(&cmd.json, "json", false, "print output as json")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if err := handler(cmd, fs.Args()); err != nil {
+		if err == errUsage {
+			fmt.Fprint(stderr, usage)
+			return 2
+		}
+		fmt.Fprintf(stderr, "readygo-config %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+func (cmd *command) get(args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	p, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	if !p.Has(args[1]) {
+		return fmt.Errorf("key %s not find", args[1])
+	}
+	value := p.Get(args[1])
+	if cmd.json {
+		return cmd.writeJSON(map[string]string{"key": args[1], "value": value})
+	}
+	fmt.Fprintln(cmd.stdout, value)
+	return nil
+}
+
+func (cmd *command) set(args []string) error {
+	if len(args) != 3 {
+		return errUsage
+	}
+	p, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	if err := p.Set(args[1], args[2]); err != nil {
+		return err
+	}
+	return cmd.save(p, args[0])
+}
+
+func (cmd *command) del(args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	p, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	if err := p.Delete(args[1]); err != nil {
+		return err
+	}
+	return cmd.save(p, args[0])
+}
+
+func (cmd *command) sections(args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	p, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for _, section := range p.Document().Sections {
+		names = append(names, section.Name)
+	}
+	return cmd.writeList(names)
+}
+
+func (cmd *command) keys(args []string) error {
+	if len(args) != 1 && len(args) != 2 {
+		return errUsage
+	}
+	p, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	keys := []string{}
+	if len(args) == 2 {
+		if _, err := p.GetSection(args[1]); err != nil {
+			return err
+		}
+		for _, section := range p.Document().Sections {
+			if section.Name != strings.ToLower(args[1]) {
+				continue
+			}
+			for _, entry := range section.Entries {
+				keys = append(keys, entry.Key)
+			}
+		}
+		return cmd.writeList(keys)
+	}
+	for _, entry := range flatten(p) {
+		keys = append(keys, entry.Key)
+	}
+	return cmd.writeList(keys)
+}
+
+func (cmd *command) validate(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	type result struct {
+		File  string `json:"file"`
+		Error string `json:"error,omitempty"`
+	}
+	var (
+		results []result
+		failed  int
+	)
+	for _, file := range args {
+		r := result{File: file}
+		if _, err := cmd.load(file); err != nil {
+			r.Error = err.Error()
+			failed++
+		}
+		results = append(results, r)
+	}
+	if cmd.json {
+		if err := cmd.writeJSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Error != "" {
+				fmt.Fprintf(cmd.stdout, "%s: %s\n", r.File, r.Error)
+			} else {
+				fmt.Fprintf(cmd.stdout, "%s: ok\n", r.File)
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files are invalid", failed, len(args))
+	}
+	return nil
+}
+
+func (cmd *command) diff(args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	from, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	to, err := cmd.load(args[1])
+	if err != nil {
+		return err
+	}
+	type change struct {
+		Key string `json:"key"`
+		Op  string `json:"op"`
+		Old string `json:"old,omitempty"`
+		New string `json:"new,omitempty"`
+	}
+	changes := []change{}
+	toEntries := flatten(to)
+	toValues := make(map[string]string, len(toEntries))
+	for _, entry := range toEntries {
+		toValues[entry.Key] = entry.Value
+	}
+	fromKeys := make(map[string]bool)
+	for _, entry := range flatten(from) {
+		fromKeys[entry.Key] = true
+		value, ok := toValues[entry.Key]
+		switch {
+		case !ok:
+			changes = append(changes, change{Key: entry.Key, Op: "removed", Old: entry.Value})
+		case value != entry.Value:
+			changes = append(changes, change{Key: entry.Key, Op: "changed", Old: entry.Value, New: value})
+		}
+	}
+	for _, entry := range toEntries {
+		if !fromKeys[entry.Key] {
+			changes = append(changes, change{Key: entry.Key, Op: "added", New: entry.Value})
+		}
+	}
+	if cmd.json {
+		return cmd.writeJSON(changes)
+	}
+	for _, c := range changes {
+		switch c.Op {
+		case "removed":
+			fmt.Fprintf(cmd.stdout, "- %s=%s\n", c.Key, c.Old)
+		case "added":
+			fmt.Fprintf(cmd.stdout, "+ %s=%s\n", c.Key, c.New)
+		default:
+			fmt.Fprintf(cmd.stdout, "- %s=%s\n+ %s=%s\n", c.Key, c.Old, c.Key, c.New)
+		}
+	}
+	return nil
+}
+
+func (cmd *command) convert(args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+	p, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	data, err := config.Convert(p, args[1])
+	if err != nil {
+		return err
+	}
+	_, err = cmd.stdout.Write(data)
+	return err
+}
+
+func (cmd *command) format(args []string) error {
+	if len(args) != 1 {
+		return errUsage
+	}
+	p, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	return cmd.save(p, args[0])
+}
+
+func (cmd *command) encrypt(args []string) error {
+	if len(args) < 2 {
+		return errUsage
+	}
+	p, err := cmd.load(args[0])
+	if err != nil {
+		return err
+	}
+	raw := make(map[string]string)
+	for _, entry := range flatten(p) {
+		raw[entry.Key] = entry.Value
+	}
+	for _, key := range args[1:] {
+		value, ok := raw[strings.ToLower(key)]
+		if !ok {
+			return fmt.Errorf("key %s not find", key)
+		}
+		if config.IsEncrypted(value) {
+			continue
+		}
+		if value, err = config.Encrypt(value); err != nil {
+			return err
+		}
+		if err := p.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return cmd.save(p, args[0])
+}
+
+func (cmd *command) genkey(args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	key, err := config.GenerateSecretKey()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.stdout, key)
+	return nil
+}
+
+// load parses the file by its adapter
+func (cmd *command) load(file string) (config.Provider, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = ioutil.ReadAll(cmd.stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	adapterName := cmd.adapter
+	if adapterName == "" {
+		if adapterName, err = config.DetectAdapter(file, data); err != nil {
+			return nil, err
+		}
+	}
+	if config.IsTemplate(file) {
+		if data, err = config.RenderTemplate(file, data); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.charset != "" {
+		if adapterName != "ini" {
+			return nil, fmt.Errorf("charset isn't supported by adapter %s", adapterName)
+		}
+		return config.NewIniConfig(config.WithCharset(cmd.charset)).ParseData(data)
+	}
+	return config.NewConfigData(adapterName, data)
+}
+
+// save writes the provider back to file in the format of its adapter
+func (cmd *command) save(p config.Provider, file string) error {
+	if file == "-" {
+		return errors.New("can't write back to stdin")
+	}
+	if config.IsTemplate(file) {
+		return errors.New("can't write back to template, edit it please")
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	adapterName := cmd.adapter
+	if adapterName == "" {
+		if adapterName, err = config.DetectAdapter(file, data); err != nil {
+			return err
+		}
+	}
+	// ini is written back in its charset and bom
+	if adapterName == "ini" {
+		return p.SaveFile(file)
+	}
+	if data, err = config.Convert(p, adapterName); err != nil {
+		return err
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, info.Mode())
+}
+
+// writeList prints one item per line or a json array
+func (cmd *command) writeList(items []string) error {
+	if cmd.json {
+		return cmd.writeJSON(items)
+	}
+	for _, item := range items {
+		fmt.Fprintln(cmd.stdout, item)
+	}
+	return nil
+}
+
+// writeJSON prints v as indented json
+func (cmd *command) writeJSON(v interface{}) error {
+	enc := json.NewEncoder(cmd.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// flatten lists entries with full keys, keys of default section have no prefix
+func flatten(p config.Provider) []config.Entry {
+	var entries []config.Entry
+	for _, section := range p.Document().Sections {
+		for _, entry := range section.Entries {
+			if section.Name != config.DefaultSection {
+				entry.Key = section.Name + "." + entry.Key
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
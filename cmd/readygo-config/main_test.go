@@ -0,0 +1,208 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Tobecoder/readygo/config"
+)
+
+const appIni = `name = app
+
+[db]
+; database host
+host = localhost
+port = 3306
+`
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "readygo-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "app.ini")
+	if err := ioutil.WriteFile(file, []byte(appIni), 0644); err != nil {
+		t.Fatal(err)
+	}
+	exec := func(args ...string) (string, int) {
+		var stdout, stderr bytes.Buffer
+		code := run(args, strings.NewReader(appIni), &stdout, &stderr)
+		return stdout.String() + stderr.String(), code
+	}
+
+	// test get
+	if out, code := exec("get", file, "db.host"); code != 0 || out != "localhost\n" {
+		t.Fatalf("get db.host: %d %q", code, out)
+	}
+	if _, code := exec("get", file, "db.user"); code != 1 {
+		t.Fatal("db.user shouldn't exist")
+	}
+	out, _ := exec("get", "-json", file, "db.port")
+	var value map[string]string
+	if err := json.Unmarshal([]byte(out), &value); err != nil || value["value"] != "3306" {
+		t.Fatalf("get -json db.port: %q", out)
+	}
+	if out, code := exec("get", "-", "db.host"); code != 0 || out != "localhost\n" {
+		t.Fatalf("get db.host from stdin: %d %q", code, out)
+	}
+	if _, code := exec("fmt", "-"); code != 1 {
+		t.Fatal("stdin can't be written back")
+	}
+	// test set and del
+	if out, code := exec("set", file, "db.user", "root"); code != 0 {
+		t.Fatalf("set db.user: %s", out)
+	}
+	if out, _ := exec("get", file, "db.user"); out != "root\n" {
+		t.Fatalf("db.user is %q", out)
+	}
+	if out, code := exec("del", file, "db.port"); code != 0 {
+		t.Fatalf("del db.port: %s", out)
+	}
+	// test sections and keys
+	if out, _ := exec("sections", file); out != "common\ndb\n" {
+		t.Fatalf("sections: %q", out)
+	}
+	if out, _ := exec("keys", file); out != "name\ndb.host\ndb.user\n" {
+		t.Fatalf("keys: %q", out)
+	}
+	if out, _ := exec("keys", "-json", file, "db"); strings.Join(strings.Fields(out), "") != `["host","user"]` {
+		t.Fatalf("keys -json db: %q", out)
+	}
+	// test validate
+	bad := filepath.Join(dir, "bad.ini")
+	ioutil.WriteFile(bad, []byte("a = b = c\n"), 0644)
+	if _, code := exec("validate", file); code != 0 {
+		t.Fatal("app.ini should be valid")
+	}
+	if _, code := exec("validate", file, bad); code != 1 {
+		t.Fatal("bad.ini shouldn't be valid")
+	}
+	// test convert and diff
+	out, code := exec("convert", file, "json")
+	if code != 0 {
+		t.Fatalf("convert: %s", out)
+	}
+	other := filepath.Join(dir, "app.json")
+	ioutil.WriteFile(other, []byte(strings.Replace(out, "localhost", "127.0.0.1", 1)), 0644)
+	if out, _ := exec("diff", file, other); out != "- db.host=localhost\n+ db.host=127.0.0.1\n" {
+		t.Fatalf("diff: %q", out)
+	}
+	// test fmt
+	if out, code := exec("fmt", file); code != 0 {
+		t.Fatalf("fmt: %s", out)
+	}
+	if data, _ := ioutil.ReadFile(file); !strings.Contains(string(data), "# database host\nhost=localhost") {
+		t.Fatalf("fmt wrote %q", data)
+	}
+	// test encrypt
+	out, code = exec("genkey")
+	if code != 0 {
+		t.Fatalf("genkey: %s", out)
+	}
+	t.Setenv(config.SecretKeyEnv, strings.TrimSpace(out))
+	if out, code := exec("encrypt", file, "db.user"); code != 0 {
+		t.Fatalf("encrypt db.user: %s", out)
+	}
+	if data, _ := ioutil.ReadFile(file); !strings.Contains(string(data), "user=ENC[AES256-GCM,") {
+		t.Fatalf("encrypt wrote %q", data)
+	}
+	if out, _ := exec("get", file, "db.user"); out != "root\n" {
+		t.Fatalf("encrypted db.user is %q", out)
+	}
+	if _, code := exec("encrypt", file, "db.aaa"); code != 1 {
+		t.Fatal("db.aaa doesn't exist")
+	}
+	// test types of json and toml are kept when writing back
+	typed := map[string]string{
+		"typed.json": `{"name": "app", "db": {"port": 5432, "ssl": true, "ratio": 0.5, "zip": "0123"}}`,
+		"typed.toml": "name = \"app\"\n[db]\nport = 5432\nssl = true\nratio = 0.5\nzip = \"0123\"\n",
+	}
+	for name, data := range typed {
+		typedFile := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(typedFile, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if out, code := exec("set", typedFile, "db.name", "y"); code != 0 {
+			t.Fatalf("set db.name of %s: %s", name, out)
+		}
+		written, _ := ioutil.ReadFile(typedFile)
+		assign := ": "
+		if strings.HasSuffix(name, ".toml") {
+			assign = " = "
+		}
+		for key, value := range map[string]string{"port": "5432", "ssl": "true", "ratio": "0.5", "zip": `"0123"`, "name": `"y"`} {
+			if strings.HasSuffix(name, ".json") {
+				key = `"` + key + `"`
+			}
+			if !strings.Contains(string(written), key+assign+value) {
+				t.Fatalf("%s doesn't contain %s%s%s:\n%s", name, key, assign, value, written)
+			}
+		}
+	}
+	// test charset is kept when writing back
+	gbkFile := filepath.Join(dir, "gbk.ini")
+	if err := ioutil.WriteFile(gbkFile, []byte("name = \xd6\xd0\xce\xc4\nport = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, code := exec("set", "-charset", "gbk", gbkFile, "port", "2"); code != 0 {
+		t.Fatalf("set port of gbk: %s", out)
+	}
+	if data, _ := ioutil.ReadFile(gbkFile); !bytes.Contains(data, []byte("name=\xd6\xd0\xce\xc4")) || !bytes.Contains(data, []byte("port=2")) {
+		t.Fatalf("gbk file is %q", data)
+	}
+	if out, code := exec("get", "-charset", "gbk", gbkFile, "name"); code != 0 || out != "中文\n" {
+		t.Fatalf("get name of gbk: %d %q", code, out)
+	}
+	utf16File := filepath.Join(dir, "utf16.ini")
+	if err := ioutil.WriteFile(utf16File, []byte("\xff\xfen\x00=\x001\x00\n\x00"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, code := exec("set", utf16File, "n", "2"); code != 0 {
+		t.Fatalf("set n of utf-16: %s", out)
+	}
+	if data, _ := ioutil.ReadFile(utf16File); !bytes.HasPrefix(data, []byte("\xff\xfe")) || !bytes.Contains(data, []byte("n\x00=\x002\x00")) {
+		t.Fatalf("utf-16 file is %q", data)
+	}
+	if _, code := exec("get", "-charset", "gbk", "-adapter", "json", gbkFile, "name"); code != 1 {
+		t.Fatal("charset of json isn't supported")
+	}
+	// test template
+	tmpl := filepath.Join(dir, "app.ini.tmpl")
+	if err := ioutil.WriteFile(tmpl, []byte("name = {{ env \"APP_NAME\" | default \"app\" }}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_NAME", "shop")
+	if out, code := exec("get", tmpl, "name"); code != 0 || out != "shop\n" {
+		t.Fatalf("get name of template: %d %q", code, out)
+	}
+	if _, code := exec("set", tmpl, "name", "x"); code != 1 {
+		t.Fatal("template shouldn't be written")
+	}
+	// test usage
+	if _, code := exec("aaa"); code != 2 {
+		t.Fatal("aaa isn't a command")
+	}
+	if _, code := exec("get", file); code != 2 {
+		t.Fatal("get needs key")
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command readygo-configgen generates a typed Go struct from a config file,
+// with constants of key names and a loader using github.com/Tobecoder/readygo/config.
+//
+// Usage:
+//
+//	readygo-configgen [-type Config] [-pkg name] [-o file] [-adapter name] <file>
+//
+// It is designed to run by go generate, such as
+//
+//	//go:generate readygo-configgen -type AppConfig -o appconfig_gen.go app.ini
+//
+// The type of field is inferred from the value in file: bool, int, float64,
+// time.Duration, []string for the values separated by ";" such as the arrays of json,
+// and string otherwise.
+// The keys of default section are the fields of type, every other section is a struct field.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Tobecoder/readygo/config"
+)
+
+const usage = `usage: readygo-configgen [-type Config] [-pkg name] [-o file] [-adapter name] <file>
+`
+
+// listSeparator separates the items of list, the same as the default of config.Separators
+const listSeparator = ";"
+
+// field is a generated struct field of config key
+type field struct {
+	name    string // go name of field
+	key     string // full config key, such as db.host
+	konst   string // name of key constant
+	kind    string // go type of field
+	comment string
+}
+
+// section is a generated struct of config section
+type section struct {
+	name    string // go name of section field, empty for default section
+	typ     string // go type of section struct
+	key     string
+	comment string
+	fields  []field
+}
+
+// generator holds the options of generating
+type generator struct {
+	typ     string
+	pkg     string
+	adapter string
+	file    string
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	g := new(generator)
+	var output string
+	fs := flag.NewFlagSet("readygo-configgen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&g.typ, "type", "Config", "name of generated struct, the loader is Load<type>")
+	fs.StringVar(&g.pkg, "pkg", os.Getenv("GOPACKAGE"), "package name, $GOPACKAGE set by go generate or main by default")
+	fs.StringVar(&output, "o", "", "output file, stdout when empty")
+	fs.StringVar(&g.adapter, "adapter", "", "config adapter, detected by file extension when empty")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || !isIdentifier(g.typ) {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	if g.pkg == "" {
+		g.pkg = "main"
+	}
+	g.file = fs.Arg(0)
+
+	src, err := g.generate()
+	if err != nil {
+		fmt.Fprintf(stderr, "readygo-configgen: %v\n", err)
+		return 1
+	}
+	if output == "" {
+		stdout.Write(src)
+		return 0
+	}
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		fmt.Fprintf(stderr, "readygo-configgen: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// generate reads the config file and returns the formatted source
+func (g *generator) generate() ([]byte, error) {
+	data, err := ioutil.ReadFile(g.file)
+	if err != nil {
+		return nil, err
+	}
+	adapterName := g.adapter
+	if adapterName == "" {
+		if adapterName, err = config.DetectAdapter(g.file, data); err != nil {
+			return nil, err
+		}
+	}
+	if config.IsTemplate(g.file) {
+		if data, err = config.RenderTemplate(g.file, data); err != nil {
+			return nil, err
+		}
+	}
+	p, err := config.NewConfigData(adapterName, data)
+	if err != nil {
+		return nil, err
+	}
+	sections := g.sections(p.Document())
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by readygo-configgen from %s; DO NOT EDIT.\n\n", filepath.Base(g.file))
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg)
+	buf.WriteString("import (\n")
+	if usesDuration(sections) {
+		buf.WriteString("\"time\"\n\n")
+	}
+	buf.WriteString("\"github.com/Tobecoder/readygo/config\"\n)\n\n")
+
+	buf.WriteString("// Keys of config\nconst (\n")
+	for _, s := range sections {
+		for _, f := range s.fields {
+			fmt.Fprintf(&buf, "%s = %q\n", f.konst, f.key)
+		}
+	}
+	buf.WriteString(")\n\n")
+
+	for _, s := range sections {
+		if s.name == "" {
+			fmt.Fprintf(&buf, "// %s is the config of %s\n", s.typ, filepath.Base(g.file))
+		} else {
+			fmt.Fprintf(&buf, "// %s is the section %s\n", s.typ, s.key)
+		}
+		fmt.Fprintf(&buf, "type %s struct {\n", s.typ)
+		for _, f := range s.fields {
+			writeComment(&buf, f.comment)
+			fmt.Fprintf(&buf, "%s %s\n", f.name, f.kind)
+		}
+		if s.name == "" {
+			for _, sub := range sections[1:] {
+				writeComment(&buf, sub.comment)
+				fmt.Fprintf(&buf, "%s %s\n", sub.name, sub.typ)
+			}
+		}
+		buf.WriteString("}\n\n")
+	}
+
+	fmt.Fprintf(&buf, "// Load%s reads the typed config from p, all missing and invalid keys are reported\n", g.typ)
+	fmt.Fprintf(&buf, "func Load%s(p config.Provider) (*%s, error) {\n", g.typ, g.typ)
+	fmt.Fprintf(&buf, "c := new(%s)\nch := config.Check(p)\n", g.typ)
+	for _, s := range sections {
+		target := "c."
+		if s.name != "" {
+			target += s.name + "."
+		}
+		for _, f := range s.fields {
+			fmt.Fprintf(&buf, "%s%s = ch.%s(%s)\n", target, f.name, checkMethod(f.kind), f.konst)
+		}
+	}
+	buf.WriteString("if err := ch.Err(); err != nil {\nreturn nil, err\n}\nreturn c, nil\n}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format source: %v", err)
+	}
+	return src, nil
+}
+
+// sections converts the document into generated structs, the default section is the first
+func (g *generator) sections(doc *config.Document) []section {
+	sections := []section{{typ: g.typ}}
+	names, consts := newNames(), newNames()
+	for _, s := range doc.Sections {
+		if s.Name == config.DefaultSection {
+			sections[0].fields = fields(s, "", "", newNames(), consts)
+			for _, f := range sections[0].fields {
+				names.used[f.name] = true
+			}
+		}
+	}
+	for _, s := range doc.Sections {
+		if s.Name == config.DefaultSection {
+			continue
+		}
+		name := names.add(s.Name)
+		sections = append(sections, section{
+			name:    name,
+			typ:     g.typ + name,
+			key:     s.Name,
+			comment: s.Comment,
+			fields:  fields(s, s.Name, name, newNames(), consts),
+		})
+	}
+	return sections
+}
+
+// fields converts the entries of section, prefix is the section name of keys,
+// and the key constants are named Key<section><field> uniquely in consts.
+func fields(s config.Section, prefix, sectionName string, names, consts *nameSet) []field {
+	var fields []field
+	for _, entry := range s.Entries {
+		key := entry.Key
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		name := names.add(entry.Key)
+		fields = append(fields, field{
+			name:    name,
+			key:     key,
+			konst:   consts.add("Key" + sectionName + name),
+			kind:    inferType(entry.Value),
+			comment: entry.Comment,
+		})
+	}
+	return fields
+}
+
+// inferType retrieves the go type of value
+func inferType(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, listSeparator) {
+		return "[]string"
+	}
+	switch strings.ToLower(value) {
+	case "true", "false", "on", "off":
+		return "bool"
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return "int64"
+		}
+		return "int"
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil && strings.ContainsAny(value, ".eE") && !strings.ContainsAny(value, "nN") {
+		return "float64"
+	}
+	if _, err := time.ParseDuration(value); err == nil {
+		return "time.Duration"
+	}
+	return "string"
+}
+
+// checkMethod retrieves the method of config.Checker reading the type
+func checkMethod(kind string) string {
+	switch kind {
+	case "[]string":
+		return "Strings"
+	case "bool":
+		return "Bool"
+	case "int":
+		return "Int"
+	case "int64":
+		return "Int64"
+	case "float64":
+		return "Float"
+	case "time.Duration":
+		return "Duration"
+	}
+	return "String"
+}
+
+func usesDuration(sections []section) bool {
+	for _, s := range sections {
+		for _, f := range s.fields {
+			if f.kind == "time.Duration" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeComment writes the config comment as go comment
+func writeComment(buf *bytes.Buffer, comment string) {
+	for _, line := range strings.Split(comment, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			fmt.Fprintf(buf, "// %s\n", line)
+		}
+	}
+}
+
+// nameSet generates unique go names in a scope
+type nameSet struct {
+	used map[string]bool
+}
+
+func newNames() *nameSet {
+	return &nameSet{used: make(map[string]bool)}
+}
+
+// add converts key like "max_conn" or "master.host" into exported name like MaxConn and MasterHost,
+// a number is appended when the name is used.
+func (n *nameSet) add(key string) string {
+	name := goName(key)
+	unique := name
+	for i := 2; n.used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	n.used[unique] = true
+	return unique
+}
+
+// goName converts key into exported go name
+func goName(key string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || !unicode.IsUpper([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// isIdentifier reports whether name is an exported go identifier
+func isIdentifier(name string) bool {
+	return name != "" && goName(name) == name
+}
@@ -0,0 +1,156 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const appIni = `name = app
+debug = on
+db_host = shared
+
+[db]
+; database host
+host = localhost
+port = 3306
+timeout = 1m30s
+ratio = 0.75
+max_id = 9999999999
+
+[php]
+engine = fpm
+`
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "readygo-configgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "app.ini")
+	if err := ioutil.WriteFile(file, []byte(appIni), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-type", "AppConfig", "-pkg", "app", file}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run: %d %s", code, stderr.String())
+	}
+	src := stdout.String()
+	typeCheck(t, src)
+	for _, want := range []string{
+		"// Code generated by readygo-configgen from app.ini; DO NOT EDIT.",
+		"package app",
+		`"time"`,
+		`KeyName      = "name"`,
+		`KeyPhpEngine = "php.engine"`,
+		`KeyDbHost2`,
+		"Debug  bool",
+		"Db     AppConfigDb",
+		"// database host",
+		"Port    int\n",
+		"Timeout time.Duration",
+		"Ratio   float64",
+		"MaxId   int64",
+		"func LoadAppConfig(p config.Provider) (*AppConfig, error) {",
+		"c.Db.Timeout = ch.Duration(KeyDbTimeout)",
+		"c.Php.Engine = ch.String(KeyPhpEngine)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Fatalf("generated source doesn't contain %q\n%s", want, src)
+		}
+	}
+
+	// test output file
+	output := filepath.Join(dir, "app_gen.go")
+	if code := run([]string{"-o", output, file}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run -o: %d %s", code, stderr.String())
+	}
+	data, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "func LoadConfig(p config.Provider) (*Config, error) {") {
+		t.Fatalf("default type: %s", data)
+	}
+
+	// test list of json
+	jsonFile := filepath.Join(dir, "app.json")
+	if err := ioutil.WriteFile(jsonFile, []byte(`{"db": {"tags": ["a", "b"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if code := run([]string{jsonFile}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run json: %d %s", code, stderr.String())
+	}
+	if src := stdout.String(); !strings.Contains(src, "Tags []string") || !strings.Contains(src, "ch.Strings(KeyDbTags)") {
+		t.Fatalf("json list: %s", src)
+	}
+	typeCheck(t, stdout.String())
+
+	// test wrong arguments
+	if code := run([]string{"-type", "appConfig", file}, &stdout, &stderr); code != 2 {
+		t.Fatal("unexported type should be rejected")
+	}
+	if code := run([]string{filepath.Join(dir, "missing.ini")}, &stdout, &stderr); code != 1 {
+		t.Fatal("missing file should fail")
+	}
+}
+
+// typeCheck checks the generated source against the config package
+func typeCheck(t *testing.T, src string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "gen.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated source: %v\n%s", err, src)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check(f.Name.Name, fset, []*ast.File{f}, nil); err != nil {
+		t.Fatalf("generated source: %v\n%s", err, src)
+	}
+}
+
+func TestInferType(t *testing.T) {
+	for value, want := range map[string]string{
+		"":          "string",
+		"localhost": "string",
+		"on":        "bool",
+		"False":     "bool",
+		"8080":      "int",
+		"-1":        "int",
+		"1.5":       "float64",
+		"1e3":       "float64",
+		"NaN":       "string",
+		"10s":       "time.Duration",
+		"a;b":       "[]string",
+		"1.2.3":     "string",
+	} {
+		if got := inferType(value); got != want {
+			t.Fatalf("inferType(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
@@ -14,46 +14,160 @@
 
 package config
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+)
 
 // Provider defines how to get and set value from configuration raw data.
 type Provider interface {
 	Set(key, value string) error // set config data
 	Get(key string) string
+	Delete(key string) error                              // delete config data
 	Has(key string) bool                                  // check config exists
 	SaveFile(filename string) error                       // save config data
 	GetSection(section string) (map[string]string, error) //
+	Document() *Document                                  // snapshot of raw data with original sort
 
 	String(key string) string
 	Strings(key string) []string
+	Ints(key string) ([]int, error)
+	Bools(key string) ([]bool, error)
+	Floats(key string) ([]float64, error)
+	StringMap(key string) (map[string]string, error)
+	SetSeparators(sep Separators) // set separators of list and map values
 	Int(key string) (int, error)
 	Int64(key string) (int64, error)
 	Bool(key string) (bool, error)
 	Float(key string) (float64, error)
+	Duration(key string) (time.Duration, error)
+	Bytes(key string) (int64, error)
+	Time(key string, layouts ...string) (time.Time, error)
 	DefaultString(key, defaultVal string) string
 	DefaultStrings(key string, defaultVal []string) []string //get string slice
 	DefaultInt(key string, defaultVal int) int
 	DefaultInt64(key string, defaultVal int64) int64
 	DefaultBool(key string, defaultVal bool) bool
 	DefaultFloat(key string, defaultVal float64) float64
+	DefaultDuration(key string, defaultVal time.Duration) time.Duration
+	DefaultBytes(key string, defaultVal int64) int64
+	DefaultTime(key string, defaultVal time.Time, layouts ...string) time.Time
+	Require(keys ...string) error // check keys exist and can be resolved, the error is KeyErrors
+	MustString(key string) string // the Must methods panic with *KeyError
+	MustInt(key string) int
+	MustInt64(key string) int64
+	MustBool(key string) bool
+	MustFloat(key string) float64
+	MustDuration(key string) time.Duration
+	// the Watch methods call fn after the value of key changes, see Container.Watch
+	Watch(key string, fn func(value string), opts ...WatchOption) (cancel func())
+	WatchInt(key string, fn func(value int), opts ...WatchOption) (cancel func())
+	WatchDuration(key string, fn func(value time.Duration), opts ...WatchOption) (cancel func())
+	Update(fn func(tx Tx) error) error      // apply changes atomically, see Container.Update
+	AddValidator(fn func(p Provider) error) // check the result of changes before applied
+}
+
+// Separators defines how list and map values are split,
+// the empty field keeps the default separator.
+type Separators struct {
+	List  string // separator of list items, default is ";"
+	Pair  string // separator of map pairs, default is ","
+	Value string // separator of map key and value, default is ":"
 }
 
 // Config defines how to parse value from configuration file and bytes data
 type Config interface {
-	Parse(key string) (Provider, error)      // parse config data from file
-	ParseData(data []byte) (Provider, error) // parse config data from byte
+	Parse(key string) (Provider, error)                // parse config data from file
+	ParseData(data []byte) (Provider, error)           // parse config data from byte
+	ParseFS(fsys fs.FS, name string) (Provider, error) // parse config data from file in fsys
+	ParseReader(r io.Reader) (Provider, error)         // parse config data from stream
+}
+
+// Writer defines how to serialize configuration document into bytes data,
+// adapters implement it to be a target of Convert.
+type Writer interface {
+	Marshal(doc *Document) ([]byte, error)
+}
+
+// Document is a format independent snapshot of configuration data.
+// Sections, entries and comments keep their original sort.
+type Document struct {
+	Sections []Section
+}
+
+// Section is a named group of entries, the default section holds top level keys.
+type Section struct {
+	Name    string
+	Comment string
+	Entries []Entry
+}
+
+// Entry is a single key and its raw value.
+type Entry struct {
+	Key     string
+	Value   string
+	Comment string
+}
+
+var (
+	adapters   = make(map[string]Config)
+	extensions = make(map[string]string) // file extension => adapter name
+	sniffers   []sniffer                 // in the order of register
+)
+
+// sniffer recognizes content of the named adapter
+type sniffer struct {
+	name  string
+	sniff func(data []byte) bool
 }
 
-var adapters = make(map[string]Config)
+// RegisterOption declares what an adapter claims when calling Register
+type RegisterOption func(name string)
+
+// WithExtensions claims the file extensions like ".yml" for the adapter
+func WithExtensions(exts ...string) RegisterOption {
+	return func(name string) {
+		for _, ext := range exts {
+			ext = strings.ToLower(ext)
+			if _, ok := extensions[ext]; ok {
+				panic("extension " + ext + " existed")
+			}
+			extensions[ext] = name
+		}
+	}
+}
+
+// WithSniffer claims the content recognized by sniff for the adapter,
+// sniff receives the leading bytes of content, such as "<?xml" for xml adapter.
+func WithSniffer(sniff func(data []byte) bool) RegisterOption {
+	return func(name string) {
+		sniffers = append(sniffers, sniffer{name: name, sniff: sniff})
+	}
+}
 
 // NewConfig adapterName is ini/json/xml/yaml.
-// fileName is the config file path.
+// fileName is the config file path, the template with TemplateExt is rendered first.
 func NewConfig(adapterName, fileName string) (Provider, error) {
 	adapter, ok := adapters[adapterName]
 	if !ok {
 		return nil, fmt.Errorf("new config: unknown adapter %s, register it first please", adapterName)
 	}
-	return adapter.Parse(fileName)
+	if IsTemplate(fileName) {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+		p, err := parseTemplate(adapter, fileName, data)
+		return withSource(p, err, fileName)
+	}
+	p, err := adapter.Parse(fileName)
+	return withSource(p, err, fileName)
 }
 
 // NewConfig adapterName is ini/json/xml/yaml.
@@ -66,8 +180,38 @@ func NewConfigData(adapterName string, data []byte) (Provider, error) {
 	return adapter.ParseData(data)
 }
 
-// Register register adaptor for config
-func Register(name string, adapter Config) {
+// NewConfigFS adapterName is ini/json/xml/yaml.
+// name is the config file path in fsys, such as embed.FS or fstest.MapFS.
+func NewConfigFS(adapterName string, fsys fs.FS, name string) (Provider, error) {
+	adapter, ok := adapters[adapterName]
+	if !ok {
+		return nil, fmt.Errorf("new config: unknown adapter %s, register it first please", adapterName)
+	}
+	if IsTemplate(name) {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		p, err := parseTemplate(adapter, name, data)
+		return withSource(p, err, name)
+	}
+	p, err := adapter.ParseFS(fsys, name)
+	return withSource(p, err, name)
+}
+
+// NewConfigReader adapterName is ini/json/xml/yaml.
+// r is the config stream, such as os.Stdin or network connection.
+func NewConfigReader(adapterName string, r io.Reader) (Provider, error) {
+	adapter, ok := adapters[adapterName]
+	if !ok {
+		return nil, fmt.Errorf("new config: unknown adapter %s, register it first please", adapterName)
+	}
+	return adapter.ParseReader(r)
+}
+
+// Register register adaptor for config,
+// opts claim the file extensions and content recognized as the adapter.
+func Register(name string, adapter Config, opts ...RegisterOption) {
 	if adapter == nil {
 		panic("adapter is nil")
 	}
@@ -75,4 +219,63 @@ func Register(name string, adapter Config) {
 		panic("adapter " + name + " existed")
 	}
 	adapters[name] = adapter
+	for _, opt := range opts {
+		opt(name)
+	}
+}
+
+// Load parses the config file by the adapter detected by DetectAdapter,
+// the file with TemplateExt like app.ini.tmpl is rendered first, see RenderTemplate.
+func Load(fileName string) (Provider, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	adapterName, err := DetectAdapter(fileName, data)
+	if err != nil {
+		return nil, err
+	}
+	if IsTemplate(fileName) {
+		p, err := parseTemplate(adapters[adapterName], fileName, data)
+		return withSource(p, err, fileName)
+	}
+	p, err := NewConfigData(adapterName, data)
+	return withSource(p, err, fileName)
+}
+
+// DetectAdapter retrieves the adapter claiming the file extension,
+// when no adapter claims it, the adapter whose sniffer recognizes data.
+func DetectAdapter(fileName string, data []byte) (string, error) {
+	if name, ok := AdapterByExtension(fileName); ok {
+		return name, nil
+	}
+	// skip utf-8 bom and leading spaces
+	data = bytes.TrimLeft(bytes.TrimPrefix(data, []byte{239, 187, 191}), " \t\r\n")
+	for _, s := range sniffers {
+		if s.sniff(data) {
+			return s.name, nil
+		}
+	}
+	return "", fmt.Errorf("detect adapter: unknown format of %s", fileName)
+}
+
+// AdapterByExtension retrieves the adapter claiming the extension of fileName,
+// the extension of template is the one before TemplateExt, like ".ini" of app.ini.tmpl.
+func AdapterByExtension(fileName string) (string, bool) {
+	name, ok := extensions[strings.ToLower(filepath.Ext(trimTemplateExt(fileName)))]
+	return name, ok
+}
+
+// Convert serializes the provider data by adapterName,
+// comments are kept when the target format supports them.
+func Convert(src Provider, adapterName string) ([]byte, error) {
+	adapter, ok := adapters[adapterName]
+	if !ok {
+		return nil, fmt.Errorf("convert: unknown adapter %s, register it first please", adapterName)
+	}
+	writer, ok := adapter.(Writer)
+	if !ok {
+		return nil, fmt.Errorf("convert: adapter %s can't write config data", adapterName)
+	}
+	return writer.Marshal(src.Document())
 }
@@ -0,0 +1,177 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// appConfigName is the base name of global and module config files
+const appConfigName = "config"
+
+// App holds the global config and the config of each module,
+// the idea comes from the application and module config of ThinkPHP.
+type App struct {
+	sync.RWMutex
+	global  Provider
+	modules map[string]*Container
+}
+
+var (
+	defaultAppMu sync.RWMutex
+	defaultApp   = NewApp(newContainer())
+)
+
+// NewApp returns an app whose global config is global
+func NewApp(global Provider) *App {
+	return &App{
+		global:  global,
+		modules: make(map[string]*Container),
+	}
+}
+
+// LoadApp parses appPath/config.ini as global config,
+// and appPath/<module>/config.ini as the config of module.
+// Any registered adapter can be used, which is chosen by the file extension.
+func LoadApp(appPath string) (*App, error) {
+	return LoadAppFS(os.DirFS(appPath), ".")
+}
+
+// LoadAppFS is like LoadApp, but reads appPath in fsys.
+func LoadAppFS(fsys fs.FS, appPath string) (*App, error) {
+	global := Provider(newContainer())
+	if file, adapter := findConfigFile(fsys, appPath); file != "" {
+		p, err := NewConfigFS(adapter, fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		global = p
+	}
+	app := NewApp(global)
+
+	dirs, err := fs.ReadDir(fsys, appPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() || strings.HasPrefix(dir.Name(), ".") {
+			continue
+		}
+		file, adapter := findConfigFile(fsys, path.Join(appPath, dir.Name()))
+		if file == "" {
+			continue
+		}
+		p, err := NewConfigFS(adapter, fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		app.SetModule(dir.Name(), p)
+	}
+	return app, nil
+}
+
+// Global retrieves the global config
+func (a *App) Global() Provider {
+	a.RLock()
+	defer a.RUnlock()
+	return a.global
+}
+
+// SetModule sets the config of module, which falls back to the global config
+func (a *App) SetModule(name string, p Provider) {
+	a.Lock()
+	defer a.Unlock()
+	c, ok := p.(*Container)
+	if !ok {
+		c = newContainerDocument(p.Document())
+	}
+	c.Lock()
+	c.parent = a.global
+	c.Unlock()
+	if global, ok := a.global.(*Container); ok {
+		global.addChild(c)
+	}
+	a.modules[strings.ToLower(name)] = c
+}
+
+// Module retrieves the config of module,
+// Get of which falls back from module to global config.
+// The global config is returned when the module has no config.
+func (a *App) Module(name string) Provider {
+	a.RLock()
+	defer a.RUnlock()
+	if c, ok := a.modules[strings.ToLower(name)]; ok {
+		return c
+	}
+	return a.global
+}
+
+// Modules retrieves the names of modules which have config in name order
+func (a *App) Modules() []string {
+	a.RLock()
+	defer a.RUnlock()
+	names := make([]string, 0, len(a.modules))
+	for name := range a.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetDefaultApp sets the app used by Global and Module
+func SetDefaultApp(a *App) {
+	defaultAppMu.Lock()
+	defer defaultAppMu.Unlock()
+	defaultApp = a
+}
+
+// getDefaultApp retrieves the app set by SetDefaultApp
+func getDefaultApp() *App {
+	defaultAppMu.RLock()
+	defer defaultAppMu.RUnlock()
+	return defaultApp
+}
+
+// Global retrieves the global config of default app
+func Global() Provider {
+	return getDefaultApp().Global()
+}
+
+// Module retrieves the module config of default app
+func Module(name string) Provider {
+	return getDefaultApp().Module(name)
+}
+
+// findConfigFile retrieves the config file in dir and its adapter,
+// which is the first one in the order of extension when several exist.
+func findConfigFile(fsys fs.FS, dir string) (file, adapter string) {
+	exts := make([]string, 0, len(extensions))
+	for ext := range extensions {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	for _, ext := range exts {
+		file = path.Join(dir, appConfigName+ext)
+		if info, err := fs.Stat(fsys, file); err == nil && !info.IsDir() {
+			return file, extensions[ext]
+		}
+	}
+	return "", ""
+}
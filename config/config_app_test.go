@@ -0,0 +1,104 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestApp(t *testing.T) {
+	appPath, err := ioutil.TempDir("", "config-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(appPath)
+	os.Mkdir(filepath.Join(appPath, "admin"), 0755)
+	os.Mkdir(filepath.Join(appPath, "index"), 0755)
+	ioutil.WriteFile(filepath.Join(appPath, "config.ini"), []byte("debug = off\n[db]\nhost = localhost\nport = 3306\n"), 0644)
+	ioutil.WriteFile(filepath.Join(appPath, "admin", "config.json"), []byte(`{"debug": true, "db": {"port": 3307}}`), 0644)
+
+	app, err := LoadApp(appPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := app.Modules(); !reflect.DeepEqual(names, []string{"admin"}) {
+		t.Fatalf("modules are %v", names)
+	}
+	SetDefaultApp(app)
+	defer SetDefaultApp(NewApp(newContainer()))
+
+	admin := Module("admin")
+	if v, _ := admin.Bool("debug"); !v {
+		t.Fatal("admin debug should be on")
+	}
+	if v, _ := admin.Int("db.port"); v != 3307 {
+		t.Fatalf("admin db.port is %d", v)
+	}
+	// fall back to global config
+	if v := admin.Get("db.host"); v != "localhost" || !admin.Has("db.host") {
+		t.Fatalf("admin db.host is %s", v)
+	}
+	if section, _ := admin.GetSection("db"); section["host"] != "localhost" || section["port"] != "3307" {
+		t.Fatalf("admin db section is %v", section)
+	}
+	// global changes are visible in module
+	Global().Set("db.user", "root")
+	if v := admin.Get("db.user"); v != "root" {
+		t.Fatalf("admin db.user is %s", v)
+	}
+	// module without config uses global config
+	if v, _ := Module("index").Bool("debug"); v {
+		t.Fatal("index debug should be off")
+	}
+	if _, err := LoadApp(filepath.Join(appPath, "aaa")); err == nil {
+		t.Fatal("app path aaa shouldn't exist")
+	}
+}
+
+func TestAppConcurrent(t *testing.T) {
+	defer SetDefaultApp(NewApp(newContainer()))
+	local, _ := NewConfigData("ini", []byte("[db]\nhost = localhost\n"))
+	remote, _ := NewConfigData("ini", []byte("[db]\nhost = remote\n"))
+	apps := []*App{NewApp(local), NewApp(remote)}
+	admin := newContainer()
+	apps[0].SetModule("admin", admin)
+	SetDefaultApp(apps[0])
+
+	// the parent of module and the default app are swapped while they are read
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(app *App) {
+			defer wg.Done()
+			app.SetModule("admin", admin)
+			SetDefaultApp(app)
+		}(apps[i%2])
+		go func() {
+			defer wg.Done()
+			if section, _ := admin.GetSection("db"); section["host"] == "" {
+				t.Error("admin db.host should fall back to global")
+			}
+			if v := Global().Get("db.host"); v == "" {
+				t.Error("global db.host is empty")
+			}
+		}()
+	}
+	wg.Wait()
+}
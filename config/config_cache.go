@@ -0,0 +1,219 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// cacheVersion is increased when the cache format changes, older caches are rebuilt
+const cacheVersion = 2
+
+// cacheData is the content of cache file
+type cacheData struct {
+	Version           int
+	Sources           []cacheSource
+	Document          *Document
+	Source            string
+	KeySources        map[string]string
+	Charset           string
+	BOM               bool
+	CaseMode          CaseMode
+	SectionDivision   string
+	AttributeDivision string
+	Separators        Separators
+}
+
+// cacheSource records the state of a source file when the cache is written
+type cacheSource struct {
+	Name    string
+	Size    int64
+	ModTime int64
+	Hash    string
+	// Rendered is the hash of rendered template, which changes with the environment
+	Rendered string
+}
+
+// LoadCached loads the config from cacheFile when none of sources is changed,
+// otherwise calls load and writes the result into cacheFile, like the config cache of ThinkPHP.
+// sources are the files read by load, which are compared by size, modification time and sha256.
+// The raw values are cached, so encrypted and file referenced values are resolved by Get as usual.
+// Templates are rendered again when loading the cache, which is rebuilt when the result changes.
+// When the cache can't be written, the loaded provider is returned with the error.
+func LoadCached(cacheFile string, sources []string, load func() (Provider, error)) (Provider, error) {
+	if c, ok := readCache(cacheFile, sources); ok {
+		return c, nil
+	}
+	// stat sources before loading, so changes during loading invalidate the cache
+	states := make([]cacheSource, 0, len(sources))
+	for _, source := range sources {
+		state, err := statSource(source, true)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	p, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if err := writeCache(cacheFile, states, p); err != nil {
+		return p, fmt.Errorf("config cache %s: %v", cacheFile, err)
+	}
+	return p, nil
+}
+
+// readCache loads the container from cache file, false is returned when it is stale or broken
+func readCache(cacheFile string, sources []string) (*Container, bool) {
+	data, err := ioutil.ReadFile(cacheFile)
+	if err != nil {
+		return nil, false
+	}
+	var cache cacheData
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&cache); err != nil {
+		return nil, false
+	}
+	if cache.Version != cacheVersion || len(cache.Sources) != len(sources) || cache.Document == nil {
+		return nil, false
+	}
+	for i, source := range sources {
+		cached := cache.Sources[i]
+		if cached.Name != source {
+			return nil, false
+		}
+		state, err := statSource(source, false)
+		if err != nil || state.Size != cached.Size {
+			return nil, false
+		}
+		// the file is touched, but may not be modified
+		if state.ModTime != cached.ModTime {
+			if state, err = statSource(source, true); err != nil || state.Hash != cached.Hash {
+				return nil, false
+			}
+		}
+		if IsTemplate(source) {
+			if rendered, err := renderedHash(source); err != nil || rendered != cached.Rendered {
+				return nil, false
+			}
+		}
+	}
+
+	c := newContainerDocument(cache.Document)
+	c.source = cache.Source
+	for k, v := range cache.KeySources {
+		c.sources[k] = v
+	}
+	c.charset, c.bom = cache.Charset, cache.BOM
+	c.caseMode = cache.CaseMode
+	c.sectionDivision, c.attributeDivision = cache.SectionDivision, cache.AttributeDivision
+	c.sep = cache.Separators
+	return c, true
+}
+
+// writeCache writes the provider into cache file atomically
+func writeCache(cacheFile string, sources []cacheSource, p Provider) error {
+	cache := cacheData{
+		Version:  cacheVersion,
+		Sources:  sources,
+		Document: p.Document(),
+	}
+	if c, ok := p.(*Container); ok {
+		c.RLock()
+		cache.Source = c.source
+		cache.KeySources = make(map[string]string, len(c.sources))
+		for k, v := range c.sources {
+			cache.KeySources[k] = v
+		}
+		cache.Charset, cache.BOM = c.charset, c.bom
+		cache.CaseMode = c.caseMode
+		cache.SectionDivision, cache.AttributeDivision = c.sectionDivision, c.attributeDivision
+		cache.Separators = c.sep
+		c.RUnlock()
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cache); err != nil {
+		return err
+	}
+	return writeFileAtomic(cacheFile, buf.Bytes())
+}
+
+// statSource retrieves the state of source file, the file is hashed when hash is true
+func statSource(name string, hash bool) (cacheSource, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return cacheSource{}, err
+	}
+	state := cacheSource{Name: name, Size: info.Size(), ModTime: info.ModTime().UnixNano()}
+	if hash {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return cacheSource{}, err
+		}
+		sum := sha256.Sum256(data)
+		state.Hash = hex.EncodeToString(sum[:])
+		if IsTemplate(name) {
+			if state.Rendered, err = renderedHash(name); err != nil {
+				return cacheSource{}, err
+			}
+		}
+	}
+	return state, nil
+}
+
+// renderedHash renders the template file and retrieves the sha256 of result
+func renderedHash(name string) (string, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	if data, err = RenderTemplate(name, data); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// writeFileAtomic writes data into a temporary file and renames it to name,
+// so readers never see a partial file.
+func writeFileAtomic(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(name), filepath.Base(name)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), name); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadCached(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "app.ini")
+	cacheFile := filepath.Join(dir, "runtime", "config.cache")
+	ioutil.WriteFile(source, []byte("; app name\nname = app\n[db]\nHost = localhost\n"), 0644)
+
+	loads := 0
+	load := func() (Provider, error) {
+		loads++
+		return NewIniConfig(WithCaseMode(CasePreserving)).Parse(source)
+	}
+	for i := 0; i < 2; i++ {
+		p, err := LoadCached(cacheFile, []string{source}, load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Get("db.host") != "localhost" || p.Document().Sections[0].Entries[0].Comment != " app name" {
+			t.Fatalf("cached config is %v", p.Document())
+		}
+		if section, _ := p.GetSection("db"); section["Host"] != "localhost" {
+			t.Fatalf("case isn't preserved, got %v", section)
+		}
+		if s := Settings(p); s[0].Source != source {
+			t.Fatalf("source is %v", s)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("config is loaded %d times", loads)
+	}
+
+	// test touched file is still cached
+	later := time.Now().Add(time.Hour)
+	os.Chtimes(source, later, later)
+	LoadCached(cacheFile, []string{source}, load)
+	if loads != 1 {
+		t.Fatal("touched file shouldn't reload")
+	}
+
+	// test modified file reloads
+	ioutil.WriteFile(source, []byte("name = app2\n"), 0644)
+	p, err := LoadCached(cacheFile, []string{source}, load)
+	if err != nil || loads != 2 || p.Get("name") != "app2" {
+		t.Fatalf("modified file should reload, got %d %v", loads, err)
+	}
+
+	// test broken cache and version
+	ioutil.WriteFile(cacheFile, []byte("aaa"), 0644)
+	LoadCached(cacheFile, []string{source}, load)
+	if loads != 3 {
+		t.Fatal("broken cache should reload")
+	}
+	if _, ok := readCache(cacheFile, []string{source, source}); ok {
+		t.Fatal("sources are changed")
+	}
+
+	// test errors
+	if _, err := LoadCached(cacheFile, []string{filepath.Join(dir, "aaa.ini")}, load); err == nil {
+		t.Fatal("aaa.ini doesn't exist")
+	}
+	loadErr := errors.New("load error")
+	ioutil.WriteFile(source, []byte("name = app3\n"), 0644)
+	if _, err := LoadCached(cacheFile, []string{source}, func() (Provider, error) { return nil, loadErr }); err != loadErr {
+		t.Fatalf("error is %v", err)
+	}
+}
+
+func TestLoadCachedTemplate(t *testing.T) {
+	defer func() { templateEnv = nil }()
+	dir := t.TempDir()
+	source := filepath.Join(dir, "app.ini.tmpl")
+	cacheFile := filepath.Join(dir, "config.cache")
+	ioutil.WriteFile(source, []byte("debug = {{ if eq .Env \"prod\" }}off{{ else }}on{{ end }}\n"), 0644)
+
+	loads := 0
+	load := func() (Provider, error) {
+		loads++
+		return Load(source)
+	}
+	// test the cache is rebuilt when the rendered result changes
+	for i, env := range []string{"dev", "dev", "prod", "prod"} {
+		SetTemplateEnv(env)
+		p, err := LoadCached(cacheFile, []string{source}, load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := map[string]string{"dev": "on", "prod": "off"}[env]; p.Get("debug") != want {
+			t.Fatalf("%s: debug is %s", env, p.Get("debug"))
+		}
+		if want := []int{1, 1, 2, 2}[i]; loads != want {
+			t.Fatalf("%s: config is loaded %d times", env, loads)
+		}
+	}
+}
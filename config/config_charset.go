@@ -0,0 +1,337 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run gen_gbk.go
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Charset converts config data between its encoding and utf-8
+type Charset interface {
+	Decode(data []byte) ([]byte, error) // decode data into utf-8
+	Encode(data []byte) ([]byte, error) // encode utf-8 data
+}
+
+// charsetUTF8 is the charset without converting
+const charsetUTF8 = "utf-8"
+
+var charsets = make(map[string]Charset)
+
+// boms are the byte order marks recognized when charset isn't given
+var boms = []struct {
+	charset string
+	bom     []byte
+}{
+	{charsetUTF8, []byte{0xef, 0xbb, 0xbf}},
+	{"utf-16le", []byte{0xff, 0xfe}},
+	{"utf-16be", []byte{0xfe, 0xff}},
+}
+
+// RegisterCharset registers charset by names, such as "big5" implemented by golang.org/x/text.
+// utf-8, utf-16le, utf-16be, latin1, gbk and gb18030 are registered by default.
+func RegisterCharset(cs Charset, names ...string) {
+	if cs == nil {
+		panic("charset is nil")
+	}
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if _, ok := charsets[name]; ok {
+			panic("charset " + name + " existed")
+		}
+		charsets[name] = cs
+	}
+}
+
+// lookupCharset retrieves the registered charset
+func lookupCharset(name string) (Charset, error) {
+	cs, ok := charsets[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown charset %s, register it first please", name)
+	}
+	return cs, nil
+}
+
+// decodeReader converts r into utf-8 by charset,
+// the charset is detected by bom when it is empty, and utf-8 without bom is default.
+// The charset and whether the data has bom are returned to encode it back.
+func decodeReader(r io.Reader, charset string) (io.Reader, string, bool, error) {
+	var cs Charset
+	if charset != "" {
+		var err error
+		if cs, err = lookupCharset(charset); err != nil {
+			return nil, "", false, err
+		}
+	}
+	buf := bufio.NewReader(r)
+	head, _ := buf.Peek(3)
+	bom := false
+	for _, b := range boms {
+		if bytes.HasPrefix(head, b.bom) && (cs == nil || cs == charsets[b.charset]) {
+			charset, cs, bom = b.charset, charsets[b.charset], true
+			buf.Discard(len(b.bom))
+			break
+		}
+	}
+	if cs == nil || cs == charsets[charsetUTF8] {
+		return buf, charsetUTF8, bom, nil
+	}
+	data, err := ioutil.ReadAll(buf)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if data, err = cs.Decode(data); err != nil {
+		return nil, "", false, fmt.Errorf("charset %s: %v", charset, err)
+	}
+	return bytes.NewReader(data), strings.ToLower(charset), bom, nil
+}
+
+// encodeData converts utf-8 data back to charset, the bom is written when bom is true
+func encodeData(data []byte, charset string, bom bool) ([]byte, error) {
+	var head []byte
+	if bom {
+		for _, b := range boms {
+			if b.charset == charset {
+				head = b.bom
+			}
+		}
+	}
+	if charset != "" && charset != charsetUTF8 {
+		cs, err := lookupCharset(charset)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = cs.Encode(data); err != nil {
+			return nil, fmt.Errorf("charset %s: %v", charset, err)
+		}
+	}
+	return append(head, data...), nil
+}
+
+// utf8Charset keeps the data
+type utf8Charset struct{}
+
+func (utf8Charset) Decode(data []byte) ([]byte, error) { return data, nil }
+func (utf8Charset) Encode(data []byte) ([]byte, error) { return data, nil }
+
+// utf16Charset converts utf-16 in the byte order
+type utf16Charset struct {
+	order binary.ByteOrder
+}
+
+func (cs utf16Charset) Decode(data []byte) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("odd length %d", len(data))
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = cs.order.Uint16(data[2*i:])
+	}
+	return []byte(string(utf16.Decode(units))), nil
+}
+
+func (cs utf16Charset) Encode(data []byte) ([]byte, error) {
+	units := utf16.Encode([]rune(string(data)))
+	out := make([]byte, 2*len(units))
+	for i, u := range units {
+		cs.order.PutUint16(out[2*i:], u)
+	}
+	return out, nil
+}
+
+// latin1Charset converts iso-8859-1
+type latin1Charset struct{}
+
+func (latin1Charset) Decode(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	for _, b := range data {
+		out = utf8.AppendRune(out, rune(b))
+	}
+	return out, nil
+}
+
+func (latin1Charset) Encode(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	for _, r := range string(data) {
+		if r > 0xff {
+			return nil, fmt.Errorf("can't encode %q", r)
+		}
+		out = append(out, byte(r))
+	}
+	return out, nil
+}
+
+// gbkCharset converts gbk by gbkTable, which is generated by gen_gbk.go,
+// gb18030 is gbk with gb18030Diff of two bytes code, and four bytes code mapped by gb18030Ranges.
+type gbkCharset struct {
+	gb18030 bool
+	once    sync.Once
+	decode  []rune
+	encode  map[rune]uint16
+	// four bytes ranges in the order of index
+	fourRanges []gb18030Range
+}
+
+// gb18030Range maps n runes from r onto the four bytes code from linear index
+type gb18030Range struct {
+	r     rune
+	index int
+	n     int
+}
+
+const (
+	// gb18030SupplementaryIndex is the linear index of 0x90308130, which is U+10000
+	gb18030SupplementaryIndex = 189000
+)
+
+func (cs *gbkCharset) init() {
+	cs.once.Do(func() {
+		cs.decode = []rune(gbkTable)
+		if cs.gb18030 {
+			for i, r := range gb18030Diff {
+				cs.decode[i] = r
+			}
+			cs.fourRanges = append([]gb18030Range(nil), gb18030Ranges...)
+			sort.Slice(cs.fourRanges, func(i, j int) bool { return cs.fourRanges[i].index < cs.fourRanges[j].index })
+		}
+		cs.encode = make(map[rune]uint16, len(cs.decode))
+		for i, r := range cs.decode {
+			if _, ok := cs.encode[r]; ok || r == utf8.RuneError {
+				continue
+			}
+			lead, trail := i/190+0x81, i%190+0x40
+			if trail >= 0x7f {
+				trail++
+			}
+			cs.encode[r] = uint16(lead<<8 | trail)
+		}
+	})
+}
+
+func (cs *gbkCharset) Decode(data []byte) ([]byte, error) {
+	cs.init()
+	out := make([]byte, 0, len(data)*3/2)
+	for i := 0; i < len(data); i++ {
+		b := data[i]
+		if b < 0x80 {
+			out = append(out, b)
+			continue
+		}
+		if b == 0x80 || b == 0xff || i+1 >= len(data) {
+			return nil, fmt.Errorf("invalid byte 0x%x at %d", b, i)
+		}
+		trail := data[i+1]
+		if cs.gb18030 && trail >= 0x30 && trail <= 0x39 {
+			r, err := cs.decodeFour(data[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%v at %d", err, i)
+			}
+			out = utf8.AppendRune(out, r)
+			i += 3
+			continue
+		}
+		if trail < 0x40 || trail == 0x7f || trail == 0xff {
+			return nil, fmt.Errorf("invalid byte 0x%x at %d", trail, i+1)
+		}
+		index := int(b-0x81)*190 + int(trail-0x40)
+		if trail > 0x7f {
+			index--
+		}
+		r := cs.decode[index]
+		if r == utf8.RuneError {
+			return nil, fmt.Errorf("undefined code 0x%x%x at %d", b, trail, i)
+		}
+		out = utf8.AppendRune(out, r)
+		i++
+	}
+	return out, nil
+}
+
+// decodeFour decodes the four bytes code of gb18030 at the head of data
+func (cs *gbkCharset) decodeFour(data []byte) (rune, error) {
+	if len(data) < 4 || data[2] < 0x81 || data[2] == 0xff || data[3] < 0x30 || data[3] > 0x39 {
+		return 0, fmt.Errorf("invalid four bytes code % x", data[:min(len(data), 4)])
+	}
+	index := ((int(data[0]-0x81)*10+int(data[1]-0x30))*126+int(data[2]-0x81))*10 + int(data[3]-0x30)
+	if index >= gb18030SupplementaryIndex && index < gb18030SupplementaryIndex+0x100000 {
+		return rune(index-gb18030SupplementaryIndex) + 0x10000, nil
+	}
+	i := sort.Search(len(cs.fourRanges), func(i int) bool { return cs.fourRanges[i].index > index }) - 1
+	if i < 0 || index >= cs.fourRanges[i].index+cs.fourRanges[i].n {
+		return 0, fmt.Errorf("undefined code % x", data[:4])
+	}
+	return cs.fourRanges[i].r + rune(index-cs.fourRanges[i].index), nil
+}
+
+func (cs *gbkCharset) Encode(data []byte) ([]byte, error) {
+	cs.init()
+	out := make([]byte, 0, len(data))
+	for _, r := range string(data) {
+		if r < 0x80 {
+			out = append(out, byte(r))
+			continue
+		}
+		if code, ok := cs.encode[r]; ok {
+			out = append(out, byte(code>>8), byte(code))
+			continue
+		}
+		index, ok := cs.encodeFour(r)
+		if !ok {
+			return nil, fmt.Errorf("can't encode %q", r)
+		}
+		b4 := byte(index%10) + 0x30
+		index /= 10
+		b3 := byte(index%126) + 0x81
+		index /= 126
+		out = append(out, byte(index/10)+0x81, byte(index%10)+0x30, b3, b4)
+	}
+	return out, nil
+}
+
+// encodeFour retrieves the linear index of four bytes code of r, which isn't two bytes code
+func (cs *gbkCharset) encodeFour(r rune) (int, bool) {
+	if !cs.gb18030 {
+		return 0, false
+	}
+	if r >= 0x10000 && r <= unicode.MaxRune {
+		return int(r-0x10000) + gb18030SupplementaryIndex, true
+	}
+	i := sort.Search(len(gb18030Ranges), func(i int) bool { return gb18030Ranges[i].r > r }) - 1
+	if i < 0 || r >= gb18030Ranges[i].r+rune(gb18030Ranges[i].n) {
+		return 0, false
+	}
+	return gb18030Ranges[i].index + int(r-gb18030Ranges[i].r), true
+}
+
+func init() {
+	RegisterCharset(utf8Charset{}, charsetUTF8, "utf8")
+	RegisterCharset(utf16Charset{order: binary.LittleEndian}, "utf-16le", "utf16le")
+	RegisterCharset(utf16Charset{order: binary.BigEndian}, "utf-16be", "utf16be")
+	RegisterCharset(latin1Charset{}, "latin1", "iso-8859-1")
+	RegisterCharset(new(gbkCharset), "gbk", "gb2312", "cp936")
+	RegisterCharset(&gbkCharset{gb18030: true}, "gb18030")
+}
@@ -0,0 +1,2371 @@
+// Code generated by gen_gbk.go; DO NOT EDIT.
+
+package config
+
+// gb18030Diff holds the two bytes code of gb18030 differing from gbkTable, by the index of gbkTable
+var gb18030Diff = map[int]rune{
+	6080:  0xe4c6,
+	6081:  0xe4c7,
+	6082:  0xe4c8,
+	6083:  0xe4c9,
+	6084:  0xe4ca,
+	6085:  0xe4cb,
+	6086:  0xe4cc,
+	6087:  0xe4cd,
+	6088:  0xe4ce,
+	6089:  0xe4cf,
+	6090:  0xe4d0,
+	6091:  0xe4d1,
+	6092:  0xe4d2,
+	6093:  0xe4d3,
+	6094:  0xe4d4,
+	6095:  0xe4d5,
+	6096:  0xe4d6,
+	6097:  0xe4d7,
+	6098:  0xe4d8,
+	6099:  0xe4d9,
+	6100:  0xe4da,
+	6101:  0xe4db,
+	6102:  0xe4dc,
+	6103:  0xe4dd,
+	6104:  0xe4de,
+	6105:  0xe4df,
+	6106:  0xe4e0,
+	6107:  0xe4e1,
+	6108:  0xe4e2,
+	6109:  0xe4e3,
+	6110:  0xe4e4,
+	6111:  0xe4e5,
+	6112:  0xe4e6,
+	6113:  0xe4e7,
+	6114:  0xe4e8,
+	6115:  0xe4e9,
+	6116:  0xe4ea,
+	6117:  0xe4eb,
+	6118:  0xe4ec,
+	6119:  0xe4ed,
+	6120:  0xe4ee,
+	6121:  0xe4ef,
+	6122:  0xe4f0,
+	6123:  0xe4f1,
+	6124:  0xe4f2,
+	6125:  0xe4f3,
+	6126:  0xe4f4,
+	6127:  0xe4f5,
+	6128:  0xe4f6,
+	6129:  0xe4f7,
+	6130:  0xe4f8,
+	6131:  0xe4f9,
+	6132:  0xe4fa,
+	6133:  0xe4fb,
+	6134:  0xe4fc,
+	6135:  0xe4fd,
+	6136:  0xe4fe,
+	6137:  0xe4ff,
+	6138:  0xe500,
+	6139:  0xe501,
+	6140:  0xe502,
+	6141:  0xe503,
+	6142:  0xe504,
+	6143:  0xe505,
+	6144:  0xe506,
+	6145:  0xe507,
+	6146:  0xe508,
+	6147:  0xe509,
+	6148:  0xe50a,
+	6149:  0xe50b,
+	6150:  0xe50c,
+	6151:  0xe50d,
+	6152:  0xe50e,
+	6153:  0xe50f,
+	6154:  0xe510,
+	6155:  0xe511,
+	6156:  0xe512,
+	6157:  0xe513,
+	6158:  0xe514,
+	6159:  0xe515,
+	6160:  0xe516,
+	6161:  0xe517,
+	6162:  0xe518,
+	6163:  0xe519,
+	6164:  0xe51a,
+	6165:  0xe51b,
+	6166:  0xe51c,
+	6167:  0xe51d,
+	6168:  0xe51e,
+	6169:  0xe51f,
+	6170:  0xe520,
+	6171:  0xe521,
+	6172:  0xe522,
+	6173:  0xe523,
+	6174:  0xe524,
+	6175:  0xe525,
+	6270:  0xe526,
+	6271:  0xe527,
+	6272:  0xe528,
+	6273:  0xe529,
+	6274:  0xe52a,
+	6275:  0xe52b,
+	6276:  0xe52c,
+	6277:  0xe52d,
+	6278:  0xe52e,
+	6279:  0xe52f,
+	6280:  0xe530,
+	6281:  0xe531,
+	6282:  0xe532,
+	6283:  0xe533,
+	6284:  0xe534,
+	6285:  0xe535,
+	6286:  0xe536,
+	6287:  0xe537,
+	6288:  0xe538,
+	6289:  0xe539,
+	6290:  0xe53a,
+	6291:  0xe53b,
+	6292:  0xe53c,
+	6293:  0xe53d,
+	6294:  0xe53e,
+	6295:  0xe53f,
+	6296:  0xe540,
+	6297:  0xe541,
+	6298:  0xe542,
+	6299:  0xe543,
+	6300:  0xe544,
+	6301:  0xe545,
+	6302:  0xe546,
+	6303:  0xe547,
+	6304:  0xe548,
+	6305:  0xe549,
+	6306:  0xe54a,
+	6307:  0xe54b,
+	6308:  0xe54c,
+	6309:  0xe54d,
+	6310:  0xe54e,
+	6311:  0xe54f,
+	6312:  0xe550,
+	6313:  0xe551,
+	6314:  0xe552,
+	6315:  0xe553,
+	6316:  0xe554,
+	6317:  0xe555,
+	6318:  0xe556,
+	6319:  0xe557,
+	6320:  0xe558,
+	6321:  0xe559,
+	6322:  0xe55a,
+	6323:  0xe55b,
+	6324:  0xe55c,
+	6325:  0xe55d,
+	6326:  0xe55e,
+	6327:  0xe55f,
+	6328:  0xe560,
+	6329:  0xe561,
+	6330:  0xe562,
+	6331:  0xe563,
+	6332:  0xe564,
+	6333:  0xe565,
+	6334:  0xe566,
+	6335:  0xe567,
+	6336:  0xe568,
+	6337:  0xe569,
+	6338:  0xe56a,
+	6339:  0xe56b,
+	6340:  0xe56c,
+	6341:  0xe56d,
+	6342:  0xe56e,
+	6343:  0xe56f,
+	6344:  0xe570,
+	6345:  0xe571,
+	6346:  0xe572,
+	6347:  0xe573,
+	6348:  0xe574,
+	6349:  0xe575,
+	6350:  0xe576,
+	6351:  0xe577,
+	6352:  0xe578,
+	6353:  0xe579,
+	6354:  0xe57a,
+	6355:  0xe57b,
+	6356:  0xe57c,
+	6357:  0xe57d,
+	6358:  0xe57e,
+	6359:  0xe57f,
+	6360:  0xe580,
+	6361:  0xe581,
+	6362:  0xe582,
+	6363:  0xe583,
+	6364:  0xe584,
+	6365:  0xe585,
+	6376:  0xe766,
+	6377:  0xe767,
+	6378:  0xe768,
+	6379:  0xe769,
+	6380:  0xe76a,
+	6381:  0xe76b,
+	6432:  0x20ac,
+	6433:  0xe76d,
+	6444:  0xe76e,
+	6445:  0xe76f,
+	6458:  0xe770,
+	6459:  0xe771,
+	6460:  0xe586,
+	6461:  0xe587,
+	6462:  0xe588,
+	6463:  0xe589,
+	6464:  0xe58a,
+	6465:  0xe58b,
+	6466:  0xe58c,
+	6467:  0xe58d,
+	6468:  0xe58e,
+	6469:  0xe58f,
+	6470:  0xe590,
+	6471:  0xe591,
+	6472:  0xe592,
+	6473:  0xe593,
+	6474:  0xe594,
+	6475:  0xe595,
+	6476:  0xe596,
+	6477:  0xe597,
+	6478:  0xe598,
+	6479:  0xe599,
+	6480:  0xe59a,
+	6481:  0xe59b,
+	6482:  0xe59c,
+	6483:  0xe59d,
+	6484:  0xe59e,
+	6485:  0xe59f,
+	6486:  0xe5a0,
+	6487:  0xe5a1,
+	6488:  0xe5a2,
+	6489:  0xe5a3,
+	6490:  0xe5a4,
+	6491:  0xe5a5,
+	6492:  0xe5a6,
+	6493:  0xe5a7,
+	6494:  0xe5a8,
+	6495:  0xe5a9,
+	6496:  0xe5aa,
+	6497:  0xe5ab,
+	6498:  0xe5ac,
+	6499:  0xe5ad,
+	6500:  0xe5ae,
+	6501:  0xe5af,
+	6502:  0xe5b0,
+	6503:  0xe5b1,
+	6504:  0xe5b2,
+	6505:  0xe5b3,
+	6506:  0xe5b4,
+	6507:  0xe5b5,
+	6508:  0xe5b6,
+	6509:  0xe5b7,
+	6510:  0xe5b8,
+	6511:  0xe5b9,
+	6512:  0xe5ba,
+	6513:  0xe5bb,
+	6514:  0xe5bc,
+	6515:  0xe5bd,
+	6516:  0xe5be,
+	6517:  0xe5bf,
+	6518:  0xe5c0,
+	6519:  0xe5c1,
+	6520:  0xe5c2,
+	6521:  0xe5c3,
+	6522:  0xe5c4,
+	6523:  0xe5c5,
+	6524:  0xe5c6,
+	6525:  0xe5c7,
+	6526:  0xe5c8,
+	6527:  0xe5c9,
+	6528:  0xe5ca,
+	6529:  0xe5cb,
+	6530:  0xe5cc,
+	6531:  0xe5cd,
+	6532:  0xe5ce,
+	6533:  0xe5cf,
+	6534:  0xe5d0,
+	6535:  0xe5d1,
+	6536:  0xe5d2,
+	6537:  0xe5d3,
+	6538:  0xe5d4,
+	6539:  0xe5d5,
+	6540:  0xe5d6,
+	6541:  0xe5d7,
+	6542:  0xe5d8,
+	6543:  0xe5d9,
+	6544:  0xe5da,
+	6545:  0xe5db,
+	6546:  0xe5dc,
+	6547:  0xe5dd,
+	6548:  0xe5de,
+	6549:  0xe5df,
+	6550:  0xe5e0,
+	6551:  0xe5e1,
+	6552:  0xe5e2,
+	6553:  0xe5e3,
+	6554:  0xe5e4,
+	6555:  0xe5e5,
+	6650:  0xe5e6,
+	6651:  0xe5e7,
+	6652:  0xe5e8,
+	6653:  0xe5e9,
+	6654:  0xe5ea,
+	6655:  0xe5eb,
+	6656:  0xe5ec,
+	6657:  0xe5ed,
+	6658:  0xe5ee,
+	6659:  0xe5ef,
+	6660:  0xe5f0,
+	6661:  0xe5f1,
+	6662:  0xe5f2,
+	6663:  0xe5f3,
+	6664:  0xe5f4,
+	6665:  0xe5f5,
+	6666:  0xe5f6,
+	6667:  0xe5f7,
+	6668:  0xe5f8,
+	6669:  0xe5f9,
+	6670:  0xe5fa,
+	6671:  0xe5fb,
+	6672:  0xe5fc,
+	6673:  0xe5fd,
+	6674:  0xe5fe,
+	6675:  0xe5ff,
+	6676:  0xe600,
+	6677:  0xe601,
+	6678:  0xe602,
+	6679:  0xe603,
+	6680:  0xe604,
+	6681:  0xe605,
+	6682:  0xe606,
+	6683:  0xe607,
+	6684:  0xe608,
+	6685:  0xe609,
+	6686:  0xe60a,
+	6687:  0xe60b,
+	6688:  0xe60c,
+	6689:  0xe60d,
+	6690:  0xe60e,
+	6691:  0xe60f,
+	6692:  0xe610,
+	6693:  0xe611,
+	6694:  0xe612,
+	6695:  0xe613,
+	6696:  0xe614,
+	6697:  0xe615,
+	6698:  0xe616,
+	6699:  0xe617,
+	6700:  0xe618,
+	6701:  0xe619,
+	6702:  0xe61a,
+	6703:  0xe61b,
+	6704:  0xe61c,
+	6705:  0xe61d,
+	6706:  0xe61e,
+	6707:  0xe61f,
+	6708:  0xe620,
+	6709:  0xe621,
+	6710:  0xe622,
+	6711:  0xe623,
+	6712:  0xe624,
+	6713:  0xe625,
+	6714:  0xe626,
+	6715:  0xe627,
+	6716:  0xe628,
+	6717:  0xe629,
+	6718:  0xe62a,
+	6719:  0xe62b,
+	6720:  0xe62c,
+	6721:  0xe62d,
+	6722:  0xe62e,
+	6723:  0xe62f,
+	6724:  0xe630,
+	6725:  0xe631,
+	6726:  0xe632,
+	6727:  0xe633,
+	6728:  0xe634,
+	6729:  0xe635,
+	6730:  0xe636,
+	6731:  0xe637,
+	6732:  0xe638,
+	6733:  0xe639,
+	6734:  0xe63a,
+	6735:  0xe63b,
+	6736:  0xe63c,
+	6737:  0xe63d,
+	6738:  0xe63e,
+	6739:  0xe63f,
+	6740:  0xe640,
+	6741:  0xe641,
+	6742:  0xe642,
+	6743:  0xe643,
+	6744:  0xe644,
+	6745:  0xe645,
+	6829:  0xe772,
+	6830:  0xe773,
+	6831:  0xe774,
+	6832:  0xe775,
+	6833:  0xe776,
+	6834:  0xe777,
+	6835:  0xe778,
+	6836:  0xe779,
+	6837:  0xe77a,
+	6838:  0xe77b,
+	6839:  0xe77c,
+	6840:  0xe646,
+	6841:  0xe647,
+	6842:  0xe648,
+	6843:  0xe649,
+	6844:  0xe64a,
+	6845:  0xe64b,
+	6846:  0xe64c,
+	6847:  0xe64d,
+	6848:  0xe64e,
+	6849:  0xe64f,
+	6850:  0xe650,
+	6851:  0xe651,
+	6852:  0xe652,
+	6853:  0xe653,
+	6854:  0xe654,
+	6855:  0xe655,
+	6856:  0xe656,
+	6857:  0xe657,
+	6858:  0xe658,
+	6859:  0xe659,
+	6860:  0xe65a,
+	6861:  0xe65b,
+	6862:  0xe65c,
+	6863:  0xe65d,
+	6864:  0xe65e,
+	6865:  0xe65f,
+	6866:  0xe660,
+	6867:  0xe661,
+	6868:  0xe662,
+	6869:  0xe663,
+	6870:  0xe664,
+	6871:  0xe665,
+	6872:  0xe666,
+	6873:  0xe667,
+	6874:  0xe668,
+	6875:  0xe669,
+	6876:  0xe66a,
+	6877:  0xe66b,
+	6878:  0xe66c,
+	6879:  0xe66d,
+	6880:  0xe66e,
+	6881:  0xe66f,
+	6882:  0xe670,
+	6883:  0xe671,
+	6884:  0xe672,
+	6885:  0xe673,
+	6886:  0xe674,
+	6887:  0xe675,
+	6888:  0xe676,
+	6889:  0xe677,
+	6890:  0xe678,
+	6891:  0xe679,
+	6892:  0xe67a,
+	6893:  0xe67b,
+	6894:  0xe67c,
+	6895:  0xe67d,
+	6896:  0xe67e,
+	6897:  0xe67f,
+	6898:  0xe680,
+	6899:  0xe681,
+	6900:  0xe682,
+	6901:  0xe683,
+	6902:  0xe684,
+	6903:  0xe685,
+	6904:  0xe686,
+	6905:  0xe687,
+	6906:  0xe688,
+	6907:  0xe689,
+	6908:  0xe68a,
+	6909:  0xe68b,
+	6910:  0xe68c,
+	6911:  0xe68d,
+	6912:  0xe68e,
+	6913:  0xe68f,
+	6914:  0xe690,
+	6915:  0xe691,
+	6916:  0xe692,
+	6917:  0xe693,
+	6918:  0xe694,
+	6919:  0xe695,
+	6920:  0xe696,
+	6921:  0xe697,
+	6922:  0xe698,
+	6923:  0xe699,
+	6924:  0xe69a,
+	6925:  0xe69b,
+	6926:  0xe69c,
+	6927:  0xe69d,
+	6928:  0xe69e,
+	6929:  0xe69f,
+	6930:  0xe6a0,
+	6931:  0xe6a1,
+	6932:  0xe6a2,
+	6933:  0xe6a3,
+	6934:  0xe6a4,
+	6935:  0xe6a5,
+	7022:  0xe77d,
+	7023:  0xe77e,
+	7024:  0xe77f,
+	7025:  0xe780,
+	7026:  0xe781,
+	7027:  0xe782,
+	7028:  0xe783,
+	7029:  0xe784,
+	7030:  0xe6a6,
+	7031:  0xe6a7,
+	7032:  0xe6a8,
+	7033:  0xe6a9,
+	7034:  0xe6aa,
+	7035:  0xe6ab,
+	7036:  0xe6ac,
+	7037:  0xe6ad,
+	7038:  0xe6ae,
+	7039:  0xe6af,
+	7040:  0xe6b0,
+	7041:  0xe6b1,
+	7042:  0xe6b2,
+	7043:  0xe6b3,
+	7044:  0xe6b4,
+	7045:  0xe6b5,
+	7046:  0xe6b6,
+	7047:  0xe6b7,
+	7048:  0xe6b8,
+	7049:  0xe6b9,
+	7050:  0xe6ba,
+	7051:  0xe6bb,
+	7052:  0xe6bc,
+	7053:  0xe6bd,
+	7054:  0xe6be,
+	7055:  0xe6bf,
+	7056:  0xe6c0,
+	7057:  0xe6c1,
+	7058:  0xe6c2,
+	7059:  0xe6c3,
+	7060:  0xe6c4,
+	7061:  0xe6c5,
+	7062:  0xe6c6,
+	7063:  0xe6c7,
+	7064:  0xe6c8,
+	7065:  0xe6c9,
+	7066:  0xe6ca,
+	7067:  0xe6cb,
+	7068:  0xe6cc,
+	7069:  0xe6cd,
+	7070:  0xe6ce,
+	7071:  0xe6cf,
+	7072:  0xe6d0,
+	7073:  0xe6d1,
+	7074:  0xe6d2,
+	7075:  0xe6d3,
+	7076:  0xe6d4,
+	7077:  0xe6d5,
+	7078:  0xe6d6,
+	7079:  0xe6d7,
+	7080:  0xe6d8,
+	7081:  0xe6d9,
+	7082:  0xe6da,
+	7083:  0xe6db,
+	7084:  0xe6dc,
+	7085:  0xe6dd,
+	7086:  0xe6de,
+	7087:  0xe6df,
+	7088:  0xe6e0,
+	7089:  0xe6e1,
+	7090:  0xe6e2,
+	7091:  0xe6e3,
+	7092:  0xe6e4,
+	7093:  0xe6e5,
+	7094:  0xe6e6,
+	7095:  0xe6e7,
+	7096:  0xe6e8,
+	7097:  0xe6e9,
+	7098:  0xe6ea,
+	7099:  0xe6eb,
+	7100:  0xe6ec,
+	7101:  0xe6ed,
+	7102:  0xe6ee,
+	7103:  0xe6ef,
+	7104:  0xe6f0,
+	7105:  0xe6f1,
+	7106:  0xe6f2,
+	7107:  0xe6f3,
+	7108:  0xe6f4,
+	7109:  0xe6f5,
+	7110:  0xe6f6,
+	7111:  0xe6f7,
+	7112:  0xe6f8,
+	7113:  0xe6f9,
+	7114:  0xe6fa,
+	7115:  0xe6fb,
+	7116:  0xe6fc,
+	7117:  0xe6fd,
+	7118:  0xe6fe,
+	7119:  0xe6ff,
+	7120:  0xe700,
+	7121:  0xe701,
+	7122:  0xe702,
+	7123:  0xe703,
+	7124:  0xe704,
+	7125:  0xe705,
+	7150:  0xe785,
+	7151:  0xe786,
+	7152:  0xe787,
+	7153:  0xe788,
+	7154:  0xe789,
+	7155:  0xe78a,
+	7156:  0xe78b,
+	7157:  0xe78c,
+	7182:  0xfe10,
+	7183:  0xfe12,
+	7184:  0xfe11,
+	7185:  0xfe13,
+	7186:  0xfe14,
+	7187:  0xfe15,
+	7188:  0xfe16,
+	7201:  0xfe17,
+	7202:  0xfe18,
+	7208:  0xfe19,
+	7211:  0xe797,
+	7212:  0xe798,
+	7213:  0xe799,
+	7214:  0xe79a,
+	7215:  0xe79b,
+	7216:  0xe79c,
+	7217:  0xe79d,
+	7218:  0xe79e,
+	7219:  0xe79f,
+	7220:  0xe706,
+	7221:  0xe707,
+	7222:  0xe708,
+	7223:  0xe709,
+	7224:  0xe70a,
+	7225:  0xe70b,
+	7226:  0xe70c,
+	7227:  0xe70d,
+	7228:  0xe70e,
+	7229:  0xe70f,
+	7230:  0xe710,
+	7231:  0xe711,
+	7232:  0xe712,
+	7233:  0xe713,
+	7234:  0xe714,
+	7235:  0xe715,
+	7236:  0xe716,
+	7237:  0xe717,
+	7238:  0xe718,
+	7239:  0xe719,
+	7240:  0xe71a,
+	7241:  0xe71b,
+	7242:  0xe71c,
+	7243:  0xe71d,
+	7244:  0xe71e,
+	7245:  0xe71f,
+	7246:  0xe720,
+	7247:  0xe721,
+	7248:  0xe722,
+	7249:  0xe723,
+	7250:  0xe724,
+	7251:  0xe725,
+	7252:  0xe726,
+	7253:  0xe727,
+	7254:  0xe728,
+	7255:  0xe729,
+	7256:  0xe72a,
+	7257:  0xe72b,
+	7258:  0xe72c,
+	7259:  0xe72d,
+	7260:  0xe72e,
+	7261:  0xe72f,
+	7262:  0xe730,
+	7263:  0xe731,
+	7264:  0xe732,
+	7265:  0xe733,
+	7266:  0xe734,
+	7267:  0xe735,
+	7268:  0xe736,
+	7269:  0xe737,
+	7270:  0xe738,
+	7271:  0xe739,
+	7272:  0xe73a,
+	7273:  0xe73b,
+	7274:  0xe73c,
+	7275:  0xe73d,
+	7276:  0xe73e,
+	7277:  0xe73f,
+	7278:  0xe740,
+	7279:  0xe741,
+	7280:  0xe742,
+	7281:  0xe743,
+	7282:  0xe744,
+	7283:  0xe745,
+	7284:  0xe746,
+	7285:  0xe747,
+	7286:  0xe748,
+	7287:  0xe749,
+	7288:  0xe74a,
+	7289:  0xe74b,
+	7290:  0xe74c,
+	7291:  0xe74d,
+	7292:  0xe74e,
+	7293:  0xe74f,
+	7294:  0xe750,
+	7295:  0xe751,
+	7296:  0xe752,
+	7297:  0xe753,
+	7298:  0xe754,
+	7299:  0xe755,
+	7300:  0xe756,
+	7301:  0xe757,
+	7302:  0xe758,
+	7303:  0xe759,
+	7304:  0xe75a,
+	7305:  0xe75b,
+	7306:  0xe75c,
+	7307:  0xe75d,
+	7308:  0xe75e,
+	7309:  0xe75f,
+	7310:  0xe760,
+	7311:  0xe761,
+	7312:  0xe762,
+	7313:  0xe763,
+	7314:  0xe764,
+	7315:  0xe765,
+	7349:  0xe7a0,
+	7350:  0xe7a1,
+	7351:  0xe7a2,
+	7352:  0xe7a3,
+	7353:  0xe7a4,
+	7354:  0xe7a5,
+	7355:  0xe7a6,
+	7356:  0xe7a7,
+	7357:  0xe7a8,
+	7358:  0xe7a9,
+	7359:  0xe7aa,
+	7360:  0xe7ab,
+	7361:  0xe7ac,
+	7362:  0xe7ad,
+	7363:  0xe7ae,
+	7397:  0xe7af,
+	7398:  0xe7b0,
+	7399:  0xe7b1,
+	7400:  0xe7b2,
+	7401:  0xe7b3,
+	7402:  0xe7b4,
+	7403:  0xe7b5,
+	7404:  0xe7b6,
+	7405:  0xe7b7,
+	7406:  0xe7b8,
+	7407:  0xe7b9,
+	7408:  0xe7ba,
+	7409:  0xe7bb,
+	7495:  0xe7bc,
+	7496:  0xe7bd,
+	7497:  0xe7be,
+	7498:  0xe7bf,
+	7499:  0xe7c0,
+	7500:  0xe7c1,
+	7501:  0xe7c2,
+	7502:  0xe7c3,
+	7503:  0xe7c4,
+	7504:  0xe7c5,
+	7505:  0xe7c6,
+	7533:  0x1e3f,
+	7536:  0x01f9,
+	7538:  0xe7c9,
+	7539:  0xe7ca,
+	7540:  0xe7cb,
+	7541:  0xe7cc,
+	7579:  0xe7cd,
+	7580:  0xe7ce,
+	7581:  0xe7cf,
+	7582:  0xe7d0,
+	7583:  0xe7d1,
+	7584:  0xe7d2,
+	7585:  0xe7d3,
+	7586:  0xe7d4,
+	7587:  0xe7d5,
+	7588:  0xe7d6,
+	7589:  0xe7d7,
+	7590:  0xe7d8,
+	7591:  0xe7d9,
+	7592:  0xe7da,
+	7593:  0xe7db,
+	7594:  0xe7dc,
+	7595:  0xe7dd,
+	7596:  0xe7de,
+	7597:  0xe7df,
+	7598:  0xe7e0,
+	7599:  0xe7e1,
+	7624:  0xe7e2,
+	7627:  0xe7e3,
+	7629:  0xe7e4,
+	7630:  0xe7e5,
+	7631:  0xe7e6,
+	7672:  0x303e,
+	7673:  0x2ff0,
+	7674:  0x2ff1,
+	7675:  0x2ff2,
+	7676:  0x2ff3,
+	7677:  0x2ff4,
+	7678:  0x2ff5,
+	7679:  0x2ff6,
+	7680:  0x2ff7,
+	7681:  0x2ff8,
+	7682:  0x2ff9,
+	7683:  0x2ffa,
+	7684:  0x2ffb,
+	7686:  0xe7f4,
+	7687:  0xe7f5,
+	7688:  0xe7f6,
+	7689:  0xe7f7,
+	7690:  0xe7f8,
+	7691:  0xe7f9,
+	7692:  0xe7fa,
+	7693:  0xe7fb,
+	7694:  0xe7fc,
+	7695:  0xe7fd,
+	7696:  0xe7fe,
+	7697:  0xe7ff,
+	7698:  0xe800,
+	7775:  0xe801,
+	7776:  0xe802,
+	7777:  0xe803,
+	7778:  0xe804,
+	7779:  0xe805,
+	7780:  0xe806,
+	7781:  0xe807,
+	7782:  0xe808,
+	7783:  0xe809,
+	7784:  0xe80a,
+	7785:  0xe80b,
+	7786:  0xe80c,
+	7787:  0xe80d,
+	7788:  0xe80e,
+	7789:  0xe80f,
+	7886:  0xe000,
+	7887:  0xe001,
+	7888:  0xe002,
+	7889:  0xe003,
+	7890:  0xe004,
+	7891:  0xe005,
+	7892:  0xe006,
+	7893:  0xe007,
+	7894:  0xe008,
+	7895:  0xe009,
+	7896:  0xe00a,
+	7897:  0xe00b,
+	7898:  0xe00c,
+	7899:  0xe00d,
+	7900:  0xe00e,
+	7901:  0xe00f,
+	7902:  0xe010,
+	7903:  0xe011,
+	7904:  0xe012,
+	7905:  0xe013,
+	7906:  0xe014,
+	7907:  0xe015,
+	7908:  0xe016,
+	7909:  0xe017,
+	7910:  0xe018,
+	7911:  0xe019,
+	7912:  0xe01a,
+	7913:  0xe01b,
+	7914:  0xe01c,
+	7915:  0xe01d,
+	7916:  0xe01e,
+	7917:  0xe01f,
+	7918:  0xe020,
+	7919:  0xe021,
+	7920:  0xe022,
+	7921:  0xe023,
+	7922:  0xe024,
+	7923:  0xe025,
+	7924:  0xe026,
+	7925:  0xe027,
+	7926:  0xe028,
+	7927:  0xe029,
+	7928:  0xe02a,
+	7929:  0xe02b,
+	7930:  0xe02c,
+	7931:  0xe02d,
+	7932:  0xe02e,
+	7933:  0xe02f,
+	7934:  0xe030,
+	7935:  0xe031,
+	7936:  0xe032,
+	7937:  0xe033,
+	7938:  0xe034,
+	7939:  0xe035,
+	7940:  0xe036,
+	7941:  0xe037,
+	7942:  0xe038,
+	7943:  0xe039,
+	7944:  0xe03a,
+	7945:  0xe03b,
+	7946:  0xe03c,
+	7947:  0xe03d,
+	7948:  0xe03e,
+	7949:  0xe03f,
+	7950:  0xe040,
+	7951:  0xe041,
+	7952:  0xe042,
+	7953:  0xe043,
+	7954:  0xe044,
+	7955:  0xe045,
+	7956:  0xe046,
+	7957:  0xe047,
+	7958:  0xe048,
+	7959:  0xe049,
+	7960:  0xe04a,
+	7961:  0xe04b,
+	7962:  0xe04c,
+	7963:  0xe04d,
+	7964:  0xe04e,
+	7965:  0xe04f,
+	7966:  0xe050,
+	7967:  0xe051,
+	7968:  0xe052,
+	7969:  0xe053,
+	7970:  0xe054,
+	7971:  0xe055,
+	7972:  0xe056,
+	7973:  0xe057,
+	7974:  0xe058,
+	7975:  0xe059,
+	7976:  0xe05a,
+	7977:  0xe05b,
+	7978:  0xe05c,
+	7979:  0xe05d,
+	8076:  0xe05e,
+	8077:  0xe05f,
+	8078:  0xe060,
+	8079:  0xe061,
+	8080:  0xe062,
+	8081:  0xe063,
+	8082:  0xe064,
+	8083:  0xe065,
+	8084:  0xe066,
+	8085:  0xe067,
+	8086:  0xe068,
+	8087:  0xe069,
+	8088:  0xe06a,
+	8089:  0xe06b,
+	8090:  0xe06c,
+	8091:  0xe06d,
+	8092:  0xe06e,
+	8093:  0xe06f,
+	8094:  0xe070,
+	8095:  0xe071,
+	8096:  0xe072,
+	8097:  0xe073,
+	8098:  0xe074,
+	8099:  0xe075,
+	8100:  0xe076,
+	8101:  0xe077,
+	8102:  0xe078,
+	8103:  0xe079,
+	8104:  0xe07a,
+	8105:  0xe07b,
+	8106:  0xe07c,
+	8107:  0xe07d,
+	8108:  0xe07e,
+	8109:  0xe07f,
+	8110:  0xe080,
+	8111:  0xe081,
+	8112:  0xe082,
+	8113:  0xe083,
+	8114:  0xe084,
+	8115:  0xe085,
+	8116:  0xe086,
+	8117:  0xe087,
+	8118:  0xe088,
+	8119:  0xe089,
+	8120:  0xe08a,
+	8121:  0xe08b,
+	8122:  0xe08c,
+	8123:  0xe08d,
+	8124:  0xe08e,
+	8125:  0xe08f,
+	8126:  0xe090,
+	8127:  0xe091,
+	8128:  0xe092,
+	8129:  0xe093,
+	8130:  0xe094,
+	8131:  0xe095,
+	8132:  0xe096,
+	8133:  0xe097,
+	8134:  0xe098,
+	8135:  0xe099,
+	8136:  0xe09a,
+	8137:  0xe09b,
+	8138:  0xe09c,
+	8139:  0xe09d,
+	8140:  0xe09e,
+	8141:  0xe09f,
+	8142:  0xe0a0,
+	8143:  0xe0a1,
+	8144:  0xe0a2,
+	8145:  0xe0a3,
+	8146:  0xe0a4,
+	8147:  0xe0a5,
+	8148:  0xe0a6,
+	8149:  0xe0a7,
+	8150:  0xe0a8,
+	8151:  0xe0a9,
+	8152:  0xe0aa,
+	8153:  0xe0ab,
+	8154:  0xe0ac,
+	8155:  0xe0ad,
+	8156:  0xe0ae,
+	8157:  0xe0af,
+	8158:  0xe0b0,
+	8159:  0xe0b1,
+	8160:  0xe0b2,
+	8161:  0xe0b3,
+	8162:  0xe0b4,
+	8163:  0xe0b5,
+	8164:  0xe0b6,
+	8165:  0xe0b7,
+	8166:  0xe0b8,
+	8167:  0xe0b9,
+	8168:  0xe0ba,
+	8169:  0xe0bb,
+	8266:  0xe0bc,
+	8267:  0xe0bd,
+	8268:  0xe0be,
+	8269:  0xe0bf,
+	8270:  0xe0c0,
+	8271:  0xe0c1,
+	8272:  0xe0c2,
+	8273:  0xe0c3,
+	8274:  0xe0c4,
+	8275:  0xe0c5,
+	8276:  0xe0c6,
+	8277:  0xe0c7,
+	8278:  0xe0c8,
+	8279:  0xe0c9,
+	8280:  0xe0ca,
+	8281:  0xe0cb,
+	8282:  0xe0cc,
+	8283:  0xe0cd,
+	8284:  0xe0ce,
+	8285:  0xe0cf,
+	8286:  0xe0d0,
+	8287:  0xe0d1,
+	8288:  0xe0d2,
+	8289:  0xe0d3,
+	8290:  0xe0d4,
+	8291:  0xe0d5,
+	8292:  0xe0d6,
+	8293:  0xe0d7,
+	8294:  0xe0d8,
+	8295:  0xe0d9,
+	8296:  0xe0da,
+	8297:  0xe0db,
+	8298:  0xe0dc,
+	8299:  0xe0dd,
+	8300:  0xe0de,
+	8301:  0xe0df,
+	8302:  0xe0e0,
+	8303:  0xe0e1,
+	8304:  0xe0e2,
+	8305:  0xe0e3,
+	8306:  0xe0e4,
+	8307:  0xe0e5,
+	8308:  0xe0e6,
+	8309:  0xe0e7,
+	8310:  0xe0e8,
+	8311:  0xe0e9,
+	8312:  0xe0ea,
+	8313:  0xe0eb,
+	8314:  0xe0ec,
+	8315:  0xe0ed,
+	8316:  0xe0ee,
+	8317:  0xe0ef,
+	8318:  0xe0f0,
+	8319:  0xe0f1,
+	8320:  0xe0f2,
+	8321:  0xe0f3,
+	8322:  0xe0f4,
+	8323:  0xe0f5,
+	8324:  0xe0f6,
+	8325:  0xe0f7,
+	8326:  0xe0f8,
+	8327:  0xe0f9,
+	8328:  0xe0fa,
+	8329:  0xe0fb,
+	8330:  0xe0fc,
+	8331:  0xe0fd,
+	8332:  0xe0fe,
+	8333:  0xe0ff,
+	8334:  0xe100,
+	8335:  0xe101,
+	8336:  0xe102,
+	8337:  0xe103,
+	8338:  0xe104,
+	8339:  0xe105,
+	8340:  0xe106,
+	8341:  0xe107,
+	8342:  0xe108,
+	8343:  0xe109,
+	8344:  0xe10a,
+	8345:  0xe10b,
+	8346:  0xe10c,
+	8347:  0xe10d,
+	8348:  0xe10e,
+	8349:  0xe10f,
+	8350:  0xe110,
+	8351:  0xe111,
+	8352:  0xe112,
+	8353:  0xe113,
+	8354:  0xe114,
+	8355:  0xe115,
+	8356:  0xe116,
+	8357:  0xe117,
+	8358:  0xe118,
+	8359:  0xe119,
+	8456:  0xe11a,
+	8457:  0xe11b,
+	8458:  0xe11c,
+	8459:  0xe11d,
+	8460:  0xe11e,
+	8461:  0xe11f,
+	8462:  0xe120,
+	8463:  0xe121,
+	8464:  0xe122,
+	8465:  0xe123,
+	8466:  0xe124,
+	8467:  0xe125,
+	8468:  0xe126,
+	8469:  0xe127,
+	8470:  0xe128,
+	8471:  0xe129,
+	8472:  0xe12a,
+	8473:  0xe12b,
+	8474:  0xe12c,
+	8475:  0xe12d,
+	8476:  0xe12e,
+	8477:  0xe12f,
+	8478:  0xe130,
+	8479:  0xe131,
+	8480:  0xe132,
+	8481:  0xe133,
+	8482:  0xe134,
+	8483:  0xe135,
+	8484:  0xe136,
+	8485:  0xe137,
+	8486:  0xe138,
+	8487:  0xe139,
+	8488:  0xe13a,
+	8489:  0xe13b,
+	8490:  0xe13c,
+	8491:  0xe13d,
+	8492:  0xe13e,
+	8493:  0xe13f,
+	8494:  0xe140,
+	8495:  0xe141,
+	8496:  0xe142,
+	8497:  0xe143,
+	8498:  0xe144,
+	8499:  0xe145,
+	8500:  0xe146,
+	8501:  0xe147,
+	8502:  0xe148,
+	8503:  0xe149,
+	8504:  0xe14a,
+	8505:  0xe14b,
+	8506:  0xe14c,
+	8507:  0xe14d,
+	8508:  0xe14e,
+	8509:  0xe14f,
+	8510:  0xe150,
+	8511:  0xe151,
+	8512:  0xe152,
+	8513:  0xe153,
+	8514:  0xe154,
+	8515:  0xe155,
+	8516:  0xe156,
+	8517:  0xe157,
+	8518:  0xe158,
+	8519:  0xe159,
+	8520:  0xe15a,
+	8521:  0xe15b,
+	8522:  0xe15c,
+	8523:  0xe15d,
+	8524:  0xe15e,
+	8525:  0xe15f,
+	8526:  0xe160,
+	8527:  0xe161,
+	8528:  0xe162,
+	8529:  0xe163,
+	8530:  0xe164,
+	8531:  0xe165,
+	8532:  0xe166,
+	8533:  0xe167,
+	8534:  0xe168,
+	8535:  0xe169,
+	8536:  0xe16a,
+	8537:  0xe16b,
+	8538:  0xe16c,
+	8539:  0xe16d,
+	8540:  0xe16e,
+	8541:  0xe16f,
+	8542:  0xe170,
+	8543:  0xe171,
+	8544:  0xe172,
+	8545:  0xe173,
+	8546:  0xe174,
+	8547:  0xe175,
+	8548:  0xe176,
+	8549:  0xe177,
+	8646:  0xe178,
+	8647:  0xe179,
+	8648:  0xe17a,
+	8649:  0xe17b,
+	8650:  0xe17c,
+	8651:  0xe17d,
+	8652:  0xe17e,
+	8653:  0xe17f,
+	8654:  0xe180,
+	8655:  0xe181,
+	8656:  0xe182,
+	8657:  0xe183,
+	8658:  0xe184,
+	8659:  0xe185,
+	8660:  0xe186,
+	8661:  0xe187,
+	8662:  0xe188,
+	8663:  0xe189,
+	8664:  0xe18a,
+	8665:  0xe18b,
+	8666:  0xe18c,
+	8667:  0xe18d,
+	8668:  0xe18e,
+	8669:  0xe18f,
+	8670:  0xe190,
+	8671:  0xe191,
+	8672:  0xe192,
+	8673:  0xe193,
+	8674:  0xe194,
+	8675:  0xe195,
+	8676:  0xe196,
+	8677:  0xe197,
+	8678:  0xe198,
+	8679:  0xe199,
+	8680:  0xe19a,
+	8681:  0xe19b,
+	8682:  0xe19c,
+	8683:  0xe19d,
+	8684:  0xe19e,
+	8685:  0xe19f,
+	8686:  0xe1a0,
+	8687:  0xe1a1,
+	8688:  0xe1a2,
+	8689:  0xe1a3,
+	8690:  0xe1a4,
+	8691:  0xe1a5,
+	8692:  0xe1a6,
+	8693:  0xe1a7,
+	8694:  0xe1a8,
+	8695:  0xe1a9,
+	8696:  0xe1aa,
+	8697:  0xe1ab,
+	8698:  0xe1ac,
+	8699:  0xe1ad,
+	8700:  0xe1ae,
+	8701:  0xe1af,
+	8702:  0xe1b0,
+	8703:  0xe1b1,
+	8704:  0xe1b2,
+	8705:  0xe1b3,
+	8706:  0xe1b4,
+	8707:  0xe1b5,
+	8708:  0xe1b6,
+	8709:  0xe1b7,
+	8710:  0xe1b8,
+	8711:  0xe1b9,
+	8712:  0xe1ba,
+	8713:  0xe1bb,
+	8714:  0xe1bc,
+	8715:  0xe1bd,
+	8716:  0xe1be,
+	8717:  0xe1bf,
+	8718:  0xe1c0,
+	8719:  0xe1c1,
+	8720:  0xe1c2,
+	8721:  0xe1c3,
+	8722:  0xe1c4,
+	8723:  0xe1c5,
+	8724:  0xe1c6,
+	8725:  0xe1c7,
+	8726:  0xe1c8,
+	8727:  0xe1c9,
+	8728:  0xe1ca,
+	8729:  0xe1cb,
+	8730:  0xe1cc,
+	8731:  0xe1cd,
+	8732:  0xe1ce,
+	8733:  0xe1cf,
+	8734:  0xe1d0,
+	8735:  0xe1d1,
+	8736:  0xe1d2,
+	8737:  0xe1d3,
+	8738:  0xe1d4,
+	8739:  0xe1d5,
+	8836:  0xe1d6,
+	8837:  0xe1d7,
+	8838:  0xe1d8,
+	8839:  0xe1d9,
+	8840:  0xe1da,
+	8841:  0xe1db,
+	8842:  0xe1dc,
+	8843:  0xe1dd,
+	8844:  0xe1de,
+	8845:  0xe1df,
+	8846:  0xe1e0,
+	8847:  0xe1e1,
+	8848:  0xe1e2,
+	8849:  0xe1e3,
+	8850:  0xe1e4,
+	8851:  0xe1e5,
+	8852:  0xe1e6,
+	8853:  0xe1e7,
+	8854:  0xe1e8,
+	8855:  0xe1e9,
+	8856:  0xe1ea,
+	8857:  0xe1eb,
+	8858:  0xe1ec,
+	8859:  0xe1ed,
+	8860:  0xe1ee,
+	8861:  0xe1ef,
+	8862:  0xe1f0,
+	8863:  0xe1f1,
+	8864:  0xe1f2,
+	8865:  0xe1f3,
+	8866:  0xe1f4,
+	8867:  0xe1f5,
+	8868:  0xe1f6,
+	8869:  0xe1f7,
+	8870:  0xe1f8,
+	8871:  0xe1f9,
+	8872:  0xe1fa,
+	8873:  0xe1fb,
+	8874:  0xe1fc,
+	8875:  0xe1fd,
+	8876:  0xe1fe,
+	8877:  0xe1ff,
+	8878:  0xe200,
+	8879:  0xe201,
+	8880:  0xe202,
+	8881:  0xe203,
+	8882:  0xe204,
+	8883:  0xe205,
+	8884:  0xe206,
+	8885:  0xe207,
+	8886:  0xe208,
+	8887:  0xe209,
+	8888:  0xe20a,
+	8889:  0xe20b,
+	8890:  0xe20c,
+	8891:  0xe20d,
+	8892:  0xe20e,
+	8893:  0xe20f,
+	8894:  0xe210,
+	8895:  0xe211,
+	8896:  0xe212,
+	8897:  0xe213,
+	8898:  0xe214,
+	8899:  0xe215,
+	8900:  0xe216,
+	8901:  0xe217,
+	8902:  0xe218,
+	8903:  0xe219,
+	8904:  0xe21a,
+	8905:  0xe21b,
+	8906:  0xe21c,
+	8907:  0xe21d,
+	8908:  0xe21e,
+	8909:  0xe21f,
+	8910:  0xe220,
+	8911:  0xe221,
+	8912:  0xe222,
+	8913:  0xe223,
+	8914:  0xe224,
+	8915:  0xe225,
+	8916:  0xe226,
+	8917:  0xe227,
+	8918:  0xe228,
+	8919:  0xe229,
+	8920:  0xe22a,
+	8921:  0xe22b,
+	8922:  0xe22c,
+	8923:  0xe22d,
+	8924:  0xe22e,
+	8925:  0xe22f,
+	8926:  0xe230,
+	8927:  0xe231,
+	8928:  0xe232,
+	8929:  0xe233,
+	16525: 0xe810,
+	16526: 0xe811,
+	16527: 0xe812,
+	16528: 0xe813,
+	16529: 0xe814,
+	22706: 0xe234,
+	22707: 0xe235,
+	22708: 0xe236,
+	22709: 0xe237,
+	22710: 0xe238,
+	22711: 0xe239,
+	22712: 0xe23a,
+	22713: 0xe23b,
+	22714: 0xe23c,
+	22715: 0xe23d,
+	22716: 0xe23e,
+	22717: 0xe23f,
+	22718: 0xe240,
+	22719: 0xe241,
+	22720: 0xe242,
+	22721: 0xe243,
+	22722: 0xe244,
+	22723: 0xe245,
+	22724: 0xe246,
+	22725: 0xe247,
+	22726: 0xe248,
+	22727: 0xe249,
+	22728: 0xe24a,
+	22729: 0xe24b,
+	22730: 0xe24c,
+	22731: 0xe24d,
+	22732: 0xe24e,
+	22733: 0xe24f,
+	22734: 0xe250,
+	22735: 0xe251,
+	22736: 0xe252,
+	22737: 0xe253,
+	22738: 0xe254,
+	22739: 0xe255,
+	22740: 0xe256,
+	22741: 0xe257,
+	22742: 0xe258,
+	22743: 0xe259,
+	22744: 0xe25a,
+	22745: 0xe25b,
+	22746: 0xe25c,
+	22747: 0xe25d,
+	22748: 0xe25e,
+	22749: 0xe25f,
+	22750: 0xe260,
+	22751: 0xe261,
+	22752: 0xe262,
+	22753: 0xe263,
+	22754: 0xe264,
+	22755: 0xe265,
+	22756: 0xe266,
+	22757: 0xe267,
+	22758: 0xe268,
+	22759: 0xe269,
+	22760: 0xe26a,
+	22761: 0xe26b,
+	22762: 0xe26c,
+	22763: 0xe26d,
+	22764: 0xe26e,
+	22765: 0xe26f,
+	22766: 0xe270,
+	22767: 0xe271,
+	22768: 0xe272,
+	22769: 0xe273,
+	22770: 0xe274,
+	22771: 0xe275,
+	22772: 0xe276,
+	22773: 0xe277,
+	22774: 0xe278,
+	22775: 0xe279,
+	22776: 0xe27a,
+	22777: 0xe27b,
+	22778: 0xe27c,
+	22779: 0xe27d,
+	22780: 0xe27e,
+	22781: 0xe27f,
+	22782: 0xe280,
+	22783: 0xe281,
+	22784: 0xe282,
+	22785: 0xe283,
+	22786: 0xe284,
+	22787: 0xe285,
+	22788: 0xe286,
+	22789: 0xe287,
+	22790: 0xe288,
+	22791: 0xe289,
+	22792: 0xe28a,
+	22793: 0xe28b,
+	22794: 0xe28c,
+	22795: 0xe28d,
+	22796: 0xe28e,
+	22797: 0xe28f,
+	22798: 0xe290,
+	22799: 0xe291,
+	22896: 0xe292,
+	22897: 0xe293,
+	22898: 0xe294,
+	22899: 0xe295,
+	22900: 0xe296,
+	22901: 0xe297,
+	22902: 0xe298,
+	22903: 0xe299,
+	22904: 0xe29a,
+	22905: 0xe29b,
+	22906: 0xe29c,
+	22907: 0xe29d,
+	22908: 0xe29e,
+	22909: 0xe29f,
+	22910: 0xe2a0,
+	22911: 0xe2a1,
+	22912: 0xe2a2,
+	22913: 0xe2a3,
+	22914: 0xe2a4,
+	22915: 0xe2a5,
+	22916: 0xe2a6,
+	22917: 0xe2a7,
+	22918: 0xe2a8,
+	22919: 0xe2a9,
+	22920: 0xe2aa,
+	22921: 0xe2ab,
+	22922: 0xe2ac,
+	22923: 0xe2ad,
+	22924: 0xe2ae,
+	22925: 0xe2af,
+	22926: 0xe2b0,
+	22927: 0xe2b1,
+	22928: 0xe2b2,
+	22929: 0xe2b3,
+	22930: 0xe2b4,
+	22931: 0xe2b5,
+	22932: 0xe2b6,
+	22933: 0xe2b7,
+	22934: 0xe2b8,
+	22935: 0xe2b9,
+	22936: 0xe2ba,
+	22937: 0xe2bb,
+	22938: 0xe2bc,
+	22939: 0xe2bd,
+	22940: 0xe2be,
+	22941: 0xe2bf,
+	22942: 0xe2c0,
+	22943: 0xe2c1,
+	22944: 0xe2c2,
+	22945: 0xe2c3,
+	22946: 0xe2c4,
+	22947: 0xe2c5,
+	22948: 0xe2c6,
+	22949: 0xe2c7,
+	22950: 0xe2c8,
+	22951: 0xe2c9,
+	22952: 0xe2ca,
+	22953: 0xe2cb,
+	22954: 0xe2cc,
+	22955: 0xe2cd,
+	22956: 0xe2ce,
+	22957: 0xe2cf,
+	22958: 0xe2d0,
+	22959: 0xe2d1,
+	22960: 0xe2d2,
+	22961: 0xe2d3,
+	22962: 0xe2d4,
+	22963: 0xe2d5,
+	22964: 0xe2d6,
+	22965: 0xe2d7,
+	22966: 0xe2d8,
+	22967: 0xe2d9,
+	22968: 0xe2da,
+	22969: 0xe2db,
+	22970: 0xe2dc,
+	22971: 0xe2dd,
+	22972: 0xe2de,
+	22973: 0xe2df,
+	22974: 0xe2e0,
+	22975: 0xe2e1,
+	22976: 0xe2e2,
+	22977: 0xe2e3,
+	22978: 0xe2e4,
+	22979: 0xe2e5,
+	22980: 0xe2e6,
+	22981: 0xe2e7,
+	22982: 0xe2e8,
+	22983: 0xe2e9,
+	22984: 0xe2ea,
+	22985: 0xe2eb,
+	22986: 0xe2ec,
+	22987: 0xe2ed,
+	22988: 0xe2ee,
+	22989: 0xe2ef,
+	23086: 0xe2f0,
+	23087: 0xe2f1,
+	23088: 0xe2f2,
+	23089: 0xe2f3,
+	23090: 0xe2f4,
+	23091: 0xe2f5,
+	23092: 0xe2f6,
+	23093: 0xe2f7,
+	23094: 0xe2f8,
+	23095: 0xe2f9,
+	23096: 0xe2fa,
+	23097: 0xe2fb,
+	23098: 0xe2fc,
+	23099: 0xe2fd,
+	23100: 0xe2fe,
+	23101: 0xe2ff,
+	23102: 0xe300,
+	23103: 0xe301,
+	23104: 0xe302,
+	23105: 0xe303,
+	23106: 0xe304,
+	23107: 0xe305,
+	23108: 0xe306,
+	23109: 0xe307,
+	23110: 0xe308,
+	23111: 0xe309,
+	23112: 0xe30a,
+	23113: 0xe30b,
+	23114: 0xe30c,
+	23115: 0xe30d,
+	23116: 0xe30e,
+	23117: 0xe30f,
+	23118: 0xe310,
+	23119: 0xe311,
+	23120: 0xe312,
+	23121: 0xe313,
+	23122: 0xe314,
+	23123: 0xe315,
+	23124: 0xe316,
+	23125: 0xe317,
+	23126: 0xe318,
+	23127: 0xe319,
+	23128: 0xe31a,
+	23129: 0xe31b,
+	23130: 0xe31c,
+	23131: 0xe31d,
+	23132: 0xe31e,
+	23133: 0xe31f,
+	23134: 0xe320,
+	23135: 0xe321,
+	23136: 0xe322,
+	23137: 0xe323,
+	23138: 0xe324,
+	23139: 0xe325,
+	23140: 0xe326,
+	23141: 0xe327,
+	23142: 0xe328,
+	23143: 0xe329,
+	23144: 0xe32a,
+	23145: 0xe32b,
+	23146: 0xe32c,
+	23147: 0xe32d,
+	23148: 0xe32e,
+	23149: 0xe32f,
+	23150: 0xe330,
+	23151: 0xe331,
+	23152: 0xe332,
+	23153: 0xe333,
+	23154: 0xe334,
+	23155: 0xe335,
+	23156: 0xe336,
+	23157: 0xe337,
+	23158: 0xe338,
+	23159: 0xe339,
+	23160: 0xe33a,
+	23161: 0xe33b,
+	23162: 0xe33c,
+	23163: 0xe33d,
+	23164: 0xe33e,
+	23165: 0xe33f,
+	23166: 0xe340,
+	23167: 0xe341,
+	23168: 0xe342,
+	23169: 0xe343,
+	23170: 0xe344,
+	23171: 0xe345,
+	23172: 0xe346,
+	23173: 0xe347,
+	23174: 0xe348,
+	23175: 0xe349,
+	23176: 0xe34a,
+	23177: 0xe34b,
+	23178: 0xe34c,
+	23179: 0xe34d,
+	23276: 0xe34e,
+	23277: 0xe34f,
+	23278: 0xe350,
+	23279: 0xe351,
+	23280: 0xe352,
+	23281: 0xe353,
+	23282: 0xe354,
+	23283: 0xe355,
+	23284: 0xe356,
+	23285: 0xe357,
+	23286: 0xe358,
+	23287: 0xe359,
+	23288: 0xe35a,
+	23289: 0xe35b,
+	23290: 0xe35c,
+	23291: 0xe35d,
+	23292: 0xe35e,
+	23293: 0xe35f,
+	23294: 0xe360,
+	23295: 0xe361,
+	23296: 0xe362,
+	23297: 0xe363,
+	23298: 0xe364,
+	23299: 0xe365,
+	23300: 0xe366,
+	23301: 0xe367,
+	23302: 0xe368,
+	23303: 0xe369,
+	23304: 0xe36a,
+	23305: 0xe36b,
+	23306: 0xe36c,
+	23307: 0xe36d,
+	23308: 0xe36e,
+	23309: 0xe36f,
+	23310: 0xe370,
+	23311: 0xe371,
+	23312: 0xe372,
+	23313: 0xe373,
+	23314: 0xe374,
+	23315: 0xe375,
+	23316: 0xe376,
+	23317: 0xe377,
+	23318: 0xe378,
+	23319: 0xe379,
+	23320: 0xe37a,
+	23321: 0xe37b,
+	23322: 0xe37c,
+	23323: 0xe37d,
+	23324: 0xe37e,
+	23325: 0xe37f,
+	23326: 0xe380,
+	23327: 0xe381,
+	23328: 0xe382,
+	23329: 0xe383,
+	23330: 0xe384,
+	23331: 0xe385,
+	23332: 0xe386,
+	23333: 0xe387,
+	23334: 0xe388,
+	23335: 0xe389,
+	23336: 0xe38a,
+	23337: 0xe38b,
+	23338: 0xe38c,
+	23339: 0xe38d,
+	23340: 0xe38e,
+	23341: 0xe38f,
+	23342: 0xe390,
+	23343: 0xe391,
+	23344: 0xe392,
+	23345: 0xe393,
+	23346: 0xe394,
+	23347: 0xe395,
+	23348: 0xe396,
+	23349: 0xe397,
+	23350: 0xe398,
+	23351: 0xe399,
+	23352: 0xe39a,
+	23353: 0xe39b,
+	23354: 0xe39c,
+	23355: 0xe39d,
+	23356: 0xe39e,
+	23357: 0xe39f,
+	23358: 0xe3a0,
+	23359: 0xe3a1,
+	23360: 0xe3a2,
+	23361: 0xe3a3,
+	23362: 0xe3a4,
+	23363: 0xe3a5,
+	23364: 0xe3a6,
+	23365: 0xe3a7,
+	23366: 0xe3a8,
+	23367: 0xe3a9,
+	23368: 0xe3aa,
+	23369: 0xe3ab,
+	23466: 0xe3ac,
+	23467: 0xe3ad,
+	23468: 0xe3ae,
+	23469: 0xe3af,
+	23470: 0xe3b0,
+	23471: 0xe3b1,
+	23472: 0xe3b2,
+	23473: 0xe3b3,
+	23474: 0xe3b4,
+	23475: 0xe3b5,
+	23476: 0xe3b6,
+	23477: 0xe3b7,
+	23478: 0xe3b8,
+	23479: 0xe3b9,
+	23480: 0xe3ba,
+	23481: 0xe3bb,
+	23482: 0xe3bc,
+	23483: 0xe3bd,
+	23484: 0xe3be,
+	23485: 0xe3bf,
+	23486: 0xe3c0,
+	23487: 0xe3c1,
+	23488: 0xe3c2,
+	23489: 0xe3c3,
+	23490: 0xe3c4,
+	23491: 0xe3c5,
+	23492: 0xe3c6,
+	23493: 0xe3c7,
+	23494: 0xe3c8,
+	23495: 0xe3c9,
+	23496: 0xe3ca,
+	23497: 0xe3cb,
+	23498: 0xe3cc,
+	23499: 0xe3cd,
+	23500: 0xe3ce,
+	23501: 0xe3cf,
+	23502: 0xe3d0,
+	23503: 0xe3d1,
+	23504: 0xe3d2,
+	23505: 0xe3d3,
+	23506: 0xe3d4,
+	23507: 0xe3d5,
+	23508: 0xe3d6,
+	23509: 0xe3d7,
+	23510: 0xe3d8,
+	23511: 0xe3d9,
+	23512: 0xe3da,
+	23513: 0xe3db,
+	23514: 0xe3dc,
+	23515: 0xe3dd,
+	23516: 0xe3de,
+	23517: 0xe3df,
+	23518: 0xe3e0,
+	23519: 0xe3e1,
+	23520: 0xe3e2,
+	23521: 0xe3e3,
+	23522: 0xe3e4,
+	23523: 0xe3e5,
+	23524: 0xe3e6,
+	23525: 0xe3e7,
+	23526: 0xe3e8,
+	23527: 0xe3e9,
+	23528: 0xe3ea,
+	23529: 0xe3eb,
+	23530: 0xe3ec,
+	23531: 0xe3ed,
+	23532: 0xe3ee,
+	23533: 0xe3ef,
+	23534: 0xe3f0,
+	23535: 0xe3f1,
+	23536: 0xe3f2,
+	23537: 0xe3f3,
+	23538: 0xe3f4,
+	23539: 0xe3f5,
+	23540: 0xe3f6,
+	23541: 0xe3f7,
+	23542: 0xe3f8,
+	23543: 0xe3f9,
+	23544: 0xe3fa,
+	23545: 0xe3fb,
+	23546: 0xe3fc,
+	23547: 0xe3fd,
+	23548: 0xe3fe,
+	23549: 0xe3ff,
+	23550: 0xe400,
+	23551: 0xe401,
+	23552: 0xe402,
+	23553: 0xe403,
+	23554: 0xe404,
+	23555: 0xe405,
+	23556: 0xe406,
+	23557: 0xe407,
+	23558: 0xe408,
+	23559: 0xe409,
+	23656: 0xe40a,
+	23657: 0xe40b,
+	23658: 0xe40c,
+	23659: 0xe40d,
+	23660: 0xe40e,
+	23661: 0xe40f,
+	23662: 0xe410,
+	23663: 0xe411,
+	23664: 0xe412,
+	23665: 0xe413,
+	23666: 0xe414,
+	23667: 0xe415,
+	23668: 0xe416,
+	23669: 0xe417,
+	23670: 0xe418,
+	23671: 0xe419,
+	23672: 0xe41a,
+	23673: 0xe41b,
+	23674: 0xe41c,
+	23675: 0xe41d,
+	23676: 0xe41e,
+	23677: 0xe41f,
+	23678: 0xe420,
+	23679: 0xe421,
+	23680: 0xe422,
+	23681: 0xe423,
+	23682: 0xe424,
+	23683: 0xe425,
+	23684: 0xe426,
+	23685: 0xe427,
+	23686: 0xe428,
+	23687: 0xe429,
+	23688: 0xe42a,
+	23689: 0xe42b,
+	23690: 0xe42c,
+	23691: 0xe42d,
+	23692: 0xe42e,
+	23693: 0xe42f,
+	23694: 0xe430,
+	23695: 0xe431,
+	23696: 0xe432,
+	23697: 0xe433,
+	23698: 0xe434,
+	23699: 0xe435,
+	23700: 0xe436,
+	23701: 0xe437,
+	23702: 0xe438,
+	23703: 0xe439,
+	23704: 0xe43a,
+	23705: 0xe43b,
+	23706: 0xe43c,
+	23707: 0xe43d,
+	23708: 0xe43e,
+	23709: 0xe43f,
+	23710: 0xe440,
+	23711: 0xe441,
+	23712: 0xe442,
+	23713: 0xe443,
+	23714: 0xe444,
+	23715: 0xe445,
+	23716: 0xe446,
+	23717: 0xe447,
+	23718: 0xe448,
+	23719: 0xe449,
+	23720: 0xe44a,
+	23721: 0xe44b,
+	23722: 0xe44c,
+	23723: 0xe44d,
+	23724: 0xe44e,
+	23725: 0xe44f,
+	23726: 0xe450,
+	23727: 0xe451,
+	23728: 0xe452,
+	23729: 0xe453,
+	23730: 0xe454,
+	23731: 0xe455,
+	23732: 0xe456,
+	23733: 0xe457,
+	23734: 0xe458,
+	23735: 0xe459,
+	23736: 0xe45a,
+	23737: 0xe45b,
+	23738: 0xe45c,
+	23739: 0xe45d,
+	23740: 0xe45e,
+	23741: 0xe45f,
+	23742: 0xe460,
+	23743: 0xe461,
+	23744: 0xe462,
+	23745: 0xe463,
+	23746: 0xe464,
+	23747: 0xe465,
+	23748: 0xe466,
+	23749: 0xe467,
+	23766: 0x2e81,
+	23767: 0x20087,
+	23768: 0x20089,
+	23769: 0x200cc,
+	23770: 0x2e84,
+	23771: 0x3473,
+	23772: 0x3447,
+	23773: 0x2e88,
+	23774: 0x2e8b,
+	23775: 0x9fb4,
+	23776: 0x359e,
+	23777: 0x361a,
+	23778: 0x360e,
+	23779: 0x2e8c,
+	23780: 0x2e97,
+	23781: 0x396e,
+	23782: 0x3918,
+	23783: 0x9fb5,
+	23784: 0x39cf,
+	23785: 0x39df,
+	23786: 0x3a73,
+	23787: 0x39d0,
+	23788: 0x9fb6,
+	23789: 0x9fb7,
+	23790: 0x3b4e,
+	23791: 0x3c6e,
+	23792: 0x3ce0,
+	23793: 0x2ea7,
+	23794: 0x215d7,
+	23795: 0x9fb8,
+	23796: 0x2eaa,
+	23797: 0x4056,
+	23798: 0x415f,
+	23799: 0x2eae,
+	23800: 0x4337,
+	23801: 0x2eb3,
+	23802: 0x2eb6,
+	23803: 0x2eb7,
+	23804: 0x2298f,
+	23805: 0x43b1,
+	23806: 0x43ac,
+	23807: 0x2ebb,
+	23808: 0x43dd,
+	23809: 0x44d6,
+	23810: 0x4661,
+	23811: 0x464c,
+	23812: 0x9fb9,
+	23813: 0x4723,
+	23814: 0x4729,
+	23815: 0x477c,
+	23816: 0x478d,
+	23817: 0x2eca,
+	23818: 0x4947,
+	23819: 0x497a,
+	23820: 0x497d,
+	23821: 0x4982,
+	23822: 0x4983,
+	23823: 0x4985,
+	23824: 0x4986,
+	23825: 0x499f,
+	23826: 0x499b,
+	23827: 0x49b7,
+	23828: 0x49b6,
+	23829: 0x9fba,
+	23830: 0x241fe,
+	23831: 0x4ca3,
+	23832: 0x4c9f,
+	23833: 0x4ca0,
+	23834: 0x4ca1,
+	23835: 0x4c77,
+	23836: 0x4ca2,
+	23837: 0x4d13,
+	23838: 0x4d14,
+	23839: 0x4d15,
+	23840: 0x4d16,
+	23841: 0x4d17,
+	23842: 0x4d18,
+	23843: 0x4d19,
+	23844: 0x4dae,
+	23845: 0x9fbb,
+	23846: 0xe468,
+	23847: 0xe469,
+	23848: 0xe46a,
+	23849: 0xe46b,
+	23850: 0xe46c,
+	23851: 0xe46d,
+	23852: 0xe46e,
+	23853: 0xe46f,
+	23854: 0xe470,
+	23855: 0xe471,
+	23856: 0xe472,
+	23857: 0xe473,
+	23858: 0xe474,
+	23859: 0xe475,
+	23860: 0xe476,
+	23861: 0xe477,
+	23862: 0xe478,
+	23863: 0xe479,
+	23864: 0xe47a,
+	23865: 0xe47b,
+	23866: 0xe47c,
+	23867: 0xe47d,
+	23868: 0xe47e,
+	23869: 0xe47f,
+	23870: 0xe480,
+	23871: 0xe481,
+	23872: 0xe482,
+	23873: 0xe483,
+	23874: 0xe484,
+	23875: 0xe485,
+	23876: 0xe486,
+	23877: 0xe487,
+	23878: 0xe488,
+	23879: 0xe489,
+	23880: 0xe48a,
+	23881: 0xe48b,
+	23882: 0xe48c,
+	23883: 0xe48d,
+	23884: 0xe48e,
+	23885: 0xe48f,
+	23886: 0xe490,
+	23887: 0xe491,
+	23888: 0xe492,
+	23889: 0xe493,
+	23890: 0xe494,
+	23891: 0xe495,
+	23892: 0xe496,
+	23893: 0xe497,
+	23894: 0xe498,
+	23895: 0xe499,
+	23896: 0xe49a,
+	23897: 0xe49b,
+	23898: 0xe49c,
+	23899: 0xe49d,
+	23900: 0xe49e,
+	23901: 0xe49f,
+	23902: 0xe4a0,
+	23903: 0xe4a1,
+	23904: 0xe4a2,
+	23905: 0xe4a3,
+	23906: 0xe4a4,
+	23907: 0xe4a5,
+	23908: 0xe4a6,
+	23909: 0xe4a7,
+	23910: 0xe4a8,
+	23911: 0xe4a9,
+	23912: 0xe4aa,
+	23913: 0xe4ab,
+	23914: 0xe4ac,
+	23915: 0xe4ad,
+	23916: 0xe4ae,
+	23917: 0xe4af,
+	23918: 0xe4b0,
+	23919: 0xe4b1,
+	23920: 0xe4b2,
+	23921: 0xe4b3,
+	23922: 0xe4b4,
+	23923: 0xe4b5,
+	23924: 0xe4b6,
+	23925: 0xe4b7,
+	23926: 0xe4b8,
+	23927: 0xe4b9,
+	23928: 0xe4ba,
+	23929: 0xe4bb,
+	23930: 0xe4bc,
+	23931: 0xe4bd,
+	23932: 0xe4be,
+	23933: 0xe4bf,
+	23934: 0xe4c0,
+	23935: 0xe4c1,
+	23936: 0xe4c2,
+	23937: 0xe4c3,
+	23938: 0xe4c4,
+	23939: 0xe4c5,
+}
+
+// gb18030Ranges maps the four bytes code in BMP in the order of rune, the linear index of code is
+// (((b1-0x81)*10+b2-0x30)*126+b3-0x81)*10+b4-0x30, and increases with rune in each range of n runes.
+var gb18030Ranges = []gb18030Range{
+	{0x0080, 0, 36},
+	{0x00a5, 36, 2},
+	{0x00a9, 38, 7},
+	{0x00b2, 45, 5},
+	{0x00b8, 50, 31},
+	{0x00d8, 81, 8},
+	{0x00e2, 89, 6},
+	{0x00eb, 95, 1},
+	{0x00ee, 96, 4},
+	{0x00f4, 100, 3},
+	{0x00f8, 103, 1},
+	{0x00fb, 104, 1},
+	{0x00fd, 105, 4},
+	{0x0102, 109, 17},
+	{0x0114, 126, 7},
+	{0x011c, 133, 15},
+	{0x012c, 148, 24},
+	{0x0145, 172, 3},
+	{0x0149, 175, 4},
+	{0x014e, 179, 29},
+	{0x016c, 208, 98},
+	{0x01cf, 306, 1},
+	{0x01d1, 307, 1},
+	{0x01d3, 308, 1},
+	{0x01d5, 309, 1},
+	{0x01d7, 310, 1},
+	{0x01d9, 311, 1},
+	{0x01db, 312, 1},
+	{0x01dd, 313, 28},
+	{0x01fa, 341, 87},
+	{0x0252, 428, 15},
+	{0x0262, 443, 101},
+	{0x02c8, 544, 1},
+	{0x02cc, 545, 13},
+	{0x02da, 558, 183},
+	{0x03a2, 741, 1},
+	{0x03aa, 742, 7},
+	{0x03c2, 749, 1},
+	{0x03ca, 750, 55},
+	{0x0402, 805, 14},
+	{0x0450, 819, 1},
+	{0x0452, 820, 6637},
+	{0x1e40, 7458, 464},
+	{0x2011, 7922, 2},
+	{0x2017, 7924, 1},
+	{0x201a, 7925, 2},
+	{0x201e, 7927, 7},
+	{0x2027, 7934, 9},
+	{0x2031, 7943, 1},
+	{0x2034, 7944, 1},
+	{0x2036, 7945, 5},
+	{0x203c, 7950, 112},
+	{0x20ad, 8062, 86},
+	{0x2104, 8148, 1},
+	{0x2106, 8149, 3},
+	{0x210a, 8152, 12},
+	{0x2117, 8164, 10},
+	{0x2122, 8174, 62},
+	{0x216c, 8236, 4},
+	{0x217a, 8240, 22},
+	{0x2194, 8262, 2},
+	{0x219a, 8264, 110},
+	{0x2209, 8374, 6},
+	{0x2210, 8380, 1},
+	{0x2212, 8381, 3},
+	{0x2216, 8384, 4},
+	{0x221b, 8388, 2},
+	{0x2221, 8390, 2},
+	{0x2224, 8392, 1},
+	{0x2226, 8393, 1},
+	{0x222c, 8394, 2},
+	{0x222f, 8396, 5},
+	{0x2238, 8401, 5},
+	{0x223e, 8406, 10},
+	{0x2249, 8416, 3},
+	{0x224d, 8419, 5},
+	{0x2253, 8424, 13},
+	{0x2262, 8437, 2},
+	{0x2268, 8439, 6},
+	{0x2270, 8445, 37},
+	{0x2296, 8482, 3},
+	{0x229a, 8485, 11},
+	{0x22a6, 8496, 25},
+	{0x22c0, 8521, 82},
+	{0x2313, 8603, 333},
+	{0x246a, 8936, 10},
+	{0x249c, 8946, 100},
+	{0x254c, 9046, 4},
+	{0x2574, 9050, 13},
+	{0x2590, 9063, 3},
+	{0x2596, 9066, 10},
+	{0x25a2, 9076, 16},
+	{0x25b4, 9092, 8},
+	{0x25be, 9100, 8},
+	{0x25c8, 9108, 3},
+	{0x25cc, 9111, 2},
+	{0x25d0, 9113, 18},
+	{0x25e6, 9131, 31},
+	{0x2607, 9162, 2},
+	{0x260a, 9164, 54},
+	{0x2641, 9218, 1},
+	{0x2643, 9219, 2110},
+	{0x2e82, 11329, 2},
+	{0x2e85, 11331, 3},
+	{0x2e89, 11334, 2},
+	{0x2e8d, 11336, 10},
+	{0x2e98, 11346, 15},
+	{0x2ea8, 11361, 2},
+	{0x2eab, 11363, 3},
+	{0x2eaf, 11366, 4},
+	{0x2eb4, 11370, 2},
+	{0x2eb8, 11372, 3},
+	{0x2ebc, 11375, 14},
+	{0x2ecb, 11389, 293},
+	{0x2ffc, 11682, 4},
+	{0x3004, 11686, 1},
+	{0x3018, 11687, 5},
+	{0x301f, 11692, 2},
+	{0x302a, 11694, 20},
+	{0x303f, 11714, 2},
+	{0x3094, 11716, 7},
+	{0x309f, 11723, 2},
+	{0x30f7, 11725, 5},
+	{0x30ff, 11730, 6},
+	{0x312a, 11736, 246},
+	{0x322a, 11982, 7},
+	{0x3232, 11989, 113},
+	{0x32a4, 12102, 234},
+	{0x3390, 12336, 12},
+	{0x339f, 12348, 2},
+	{0x33a2, 12350, 34},
+	{0x33c5, 12384, 9},
+	{0x33cf, 12393, 2},
+	{0x33d3, 12395, 2},
+	{0x33d6, 12397, 113},
+	{0x3448, 12510, 43},
+	{0x3474, 12553, 298},
+	{0x359f, 12851, 111},
+	{0x360f, 12962, 11},
+	{0x361b, 12973, 765},
+	{0x3919, 13738, 85},
+	{0x396f, 13823, 96},
+	{0x39d1, 13919, 14},
+	{0x39e0, 13933, 147},
+	{0x3a74, 14080, 218},
+	{0x3b4f, 14298, 287},
+	{0x3c6f, 14585, 113},
+	{0x3ce1, 14698, 885},
+	{0x4057, 15583, 264},
+	{0x4160, 15847, 471},
+	{0x4338, 16318, 116},
+	{0x43ad, 16434, 4},
+	{0x43b2, 16438, 43},
+	{0x43de, 16481, 248},
+	{0x44d7, 16729, 373},
+	{0x464d, 17102, 20},
+	{0x4662, 17122, 193},
+	{0x4724, 17315, 5},
+	{0x472a, 17320, 82},
+	{0x477d, 17402, 16},
+	{0x478e, 17418, 441},
+	{0x4948, 17859, 50},
+	{0x497b, 17909, 2},
+	{0x497e, 17911, 4},
+	{0x4984, 17915, 1},
+	{0x4987, 17916, 20},
+	{0x499c, 17936, 3},
+	{0x49a0, 17939, 22},
+	{0x49b8, 17961, 703},
+	{0x4c78, 18664, 39},
+	{0x4ca4, 18703, 111},
+	{0x4d1a, 18814, 148},
+	{0x4daf, 18962, 81},
+	{0x9fa6, 19043, 14},
+	{0x9fbc, 19065, 14404},
+	{0xe76c, 33469, 1},
+	{0xe7c7, 7457, 1},
+	{0xe7c8, 33470, 1},
+	{0xe7e7, 33471, 13},
+	{0xe815, 33484, 1},
+	{0xe819, 33485, 5},
+	{0xe81f, 33490, 7},
+	{0xe827, 33497, 4},
+	{0xe82d, 33501, 4},
+	{0xe833, 33505, 8},
+	{0xe83c, 33513, 7},
+	{0xe844, 33520, 16},
+	{0xe856, 33536, 14},
+	{0xe865, 33550, 4295},
+	{0xf92d, 37845, 76},
+	{0xf97a, 37921, 27},
+	{0xf996, 37948, 81},
+	{0xf9e8, 38029, 9},
+	{0xf9f2, 38038, 26},
+	{0xfa10, 38064, 1},
+	{0xfa12, 38065, 1},
+	{0xfa15, 38066, 3},
+	{0xfa19, 38069, 6},
+	{0xfa22, 38075, 1},
+	{0xfa25, 38076, 2},
+	{0xfa2a, 38078, 998},
+	{0xfe1a, 39086, 22},
+	{0xfe32, 39108, 1},
+	{0xfe45, 39109, 4},
+	{0xfe53, 39113, 1},
+	{0xfe58, 39114, 1},
+	{0xfe67, 39115, 1},
+	{0xfe6c, 39116, 149},
+	{0xff5f, 39265, 129},
+	{0xffe6, 39394, 26},
+}
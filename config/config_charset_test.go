@@ -0,0 +1,161 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestCharset(t *testing.T) {
+	// README.md is gbk encoded
+	readme, err := ioutil.ReadFile("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gbk, _ := lookupCharset("gbk")
+	decoded, err := gbk.Decode(readme)
+	if err != nil || !strings.Contains(string(decoded), "## 使用") {
+		t.Fatalf("README.md is decoded as %s %v", decoded, err)
+	}
+	if encoded, err := gbk.Encode(decoded); err != nil || !bytes.Equal(encoded, readme) {
+		t.Fatalf("README.md isn't encoded back, %v", err)
+	}
+	if _, err := gbk.Decode([]byte{0xc4}); err == nil {
+		t.Fatal("half code isn't gbk")
+	}
+	if _, err := gbk.Encode([]byte("😀")); err == nil {
+		t.Fatal("emoji can't be encoded by gbk")
+	}
+
+	// test gb18030, the codes are converted by iconv
+	gb18030, _ := lookupCharset("gb18030")
+	for r, code := range map[rune]string{
+		0x80:     "\x81\x30\x81\x30",
+		0xff:     "\x81\x30\x8b\x37",
+		0x2014:   "\xa1\xaa",
+		0x20ac:   "\xa2\xe3",
+		0x4e2d:   "\xd6\xd0",
+		0xe000:   "\xaa\xa1",
+		0xe5e5:   "\xa3\xa0",
+		0xffff:   "\x84\x31\xa4\x39",
+		0x1f600:  "\x94\x39\xfc\x36",
+		0x10ffff: "\xe3\x32\x9a\x35",
+	} {
+		if encoded, err := gb18030.Encode([]byte(string(r))); err != nil || string(encoded) != code {
+			t.Fatalf("gb18030 of %U is % x %v", r, encoded, err)
+		}
+		if decoded, err := gb18030.Decode([]byte(code)); err != nil || string(decoded) != string(r) {
+			t.Fatalf("gb18030 % x is %q %v", code, decoded, err)
+		}
+	}
+	// the surrogates and private use area, where gb18030-2022 drops some codes, are skipped
+	var all []rune
+	for r := rune(0x80); r <= 0x10ffff; r += 1 + r/0x10000*97 {
+		if r < 0xd800 || r > 0xf8ff {
+			all = append(all, r)
+		}
+	}
+	encoded, err := gb18030.Encode([]byte(string(all)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded, err := gb18030.Decode(encoded); err != nil || string(decoded) != string(all) {
+		t.Fatalf("gb18030 round trip: %v", err)
+	}
+	if _, err := gb18030.Decode([]byte("\x81\x30\x81")); err == nil {
+		t.Fatal("half four bytes code isn't gb18030")
+	}
+	if _, err := gb18030.Decode([]byte("\x84\x31\xa5\x30")); err == nil {
+		t.Fatal("code after U+FFFF isn't defined")
+	}
+
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		charset string
+		data    []byte
+	}{
+		{"gbk.ini", "gbk", []byte("; \xca\xfd\xbe\xdd\xbf\xe2\n[db]\nname = \xd6\xd0\xce\xc4\n")},
+		{"utf16le.ini", "", utf16Data(true, "; 数据库\n[db]\nname = 中文\n")},
+		{"utf16be.ini", "", utf16Data(false, "; 数据库\r\n[db]\r\nname = 中文\r\n")},
+		{"utf8bom.ini", "", append([]byte{0xef, 0xbb, 0xbf}, "; 数据库\n[db]\nname = 中文\n"...)},
+	}
+	for _, test := range tests {
+		file := filepath.Join(dir, test.name)
+		ioutil.WriteFile(file, test.data, 0644)
+		var opts []IniOption
+		if test.charset != "" {
+			opts = append(opts, WithCharset(test.charset))
+		}
+		p, err := NewIniConfig(opts...).Parse(file)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if v := p.Get("db.name"); v != "中文" {
+			t.Fatalf("%s: db.name is %q", test.name, v)
+		}
+		if doc := p.Document(); doc.Sections[0].Comment != " 数据库" {
+			t.Fatalf("%s: comment is %v", test.name, doc.Sections)
+		}
+		// test written back in the same charset
+		p.Set("db.name", "汉字")
+		if err := p.SaveFile(file); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		saved, _ := ioutil.ReadFile(file)
+		if test.charset == "" && !bytes.Equal(saved[:2], test.data[:2]) ||
+			test.charset == "gbk" && !bytes.Contains(saved, []byte("name=\xba\xba\xd7\xd6")) {
+			t.Fatalf("%s: saved %q", test.name, saved)
+		}
+		p, err = NewIniConfig(opts...).Parse(file)
+		if err != nil || p.Get("db.name") != "汉字" {
+			t.Fatalf("%s: saved %q %v", test.name, saved, err)
+		}
+	}
+
+	// test errors
+	if _, err := NewIniConfig(WithCharset("aaa")).ParseData([]byte("a = b")); err == nil {
+		t.Fatal("aaa isn't charset")
+	}
+	p, _ := NewIniConfig(WithCharset("latin1")).ParseData([]byte("name = caf\xe9\n"))
+	if v := p.Get("name"); v != "café" {
+		t.Fatalf("name is %q", v)
+	}
+	p.Set("name", "中文")
+	if err := p.SaveFile(filepath.Join(dir, "latin1.ini")); err == nil {
+		t.Fatal("中文 can't be encoded by latin1")
+	}
+}
+
+// utf16Data encodes s into utf-16 with bom
+func utf16Data(littleEndian bool, s string) []byte {
+	data := []byte{0xfe, 0xff}
+	if littleEndian {
+		data = []byte{0xff, 0xfe}
+	}
+	for _, u := range utf16.Encode([]rune(s)) {
+		if littleEndian {
+			data = append(data, byte(u), byte(u>>8))
+		} else {
+			data = append(data, byte(u>>8), byte(u))
+		}
+	}
+	return data
+}
@@ -0,0 +1,121 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "time"
+
+// Checker reads values like Provider and collects the error of every missing,
+// unresolvable or unparsable key, so a broken config fails fast at startup:
+//
+//	ch := config.Check(p)
+//	host := ch.String("db.host")
+//	port := ch.Int("db.port")
+//	if err := ch.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+type Checker struct {
+	p    Provider
+	errs KeyErrors
+}
+
+// Check returns a Checker reading p
+func Check(p Provider) *Checker {
+	return &Checker{p: p}
+}
+
+// Err returns KeyErrors listing every missing, unresolvable or unparsable key, or nil
+func (ch *Checker) Err() error {
+	if len(ch.errs) > 0 {
+		return ch.errs
+	}
+	return nil
+}
+
+// String returns the string value for a given key
+func (ch *Checker) String(key string) string {
+	return checkValue(ch, key, Provider.String)
+}
+
+// Strings returns the []string value for a given key
+func (ch *Checker) Strings(key string) []string {
+	return checkValue(ch, key, Provider.Strings)
+}
+
+// Int returns the integer value for a given key
+func (ch *Checker) Int(key string) int {
+	return checkParse(ch, key, Provider.Int)
+}
+
+// Int64 returns the int64 value for a given key
+func (ch *Checker) Int64(key string) int64 {
+	return checkParse(ch, key, Provider.Int64)
+}
+
+// Bool returns the boolean value for a given key
+func (ch *Checker) Bool(key string) bool {
+	return checkParse(ch, key, Provider.Bool)
+}
+
+// Float returns the float64 value for a given key
+func (ch *Checker) Float(key string) float64 {
+	return checkParse(ch, key, Provider.Float)
+}
+
+// Duration returns the duration value for a given key
+func (ch *Checker) Duration(key string) time.Duration {
+	return checkParse(ch, key, Provider.Duration)
+}
+
+// Bytes returns the number of bytes for a given key
+func (ch *Checker) Bytes(key string) int64 {
+	return checkParse(ch, key, Provider.Bytes)
+}
+
+// CheckAs returns the value of key as T like Get, and collects the error into ch
+func CheckAs[T any](ch *Checker, key string) T {
+	v, err := Get[T](ch.p, key)
+	if err != nil {
+		ch.errs = append(ch.errs, err.(*KeyError))
+	}
+	return v
+}
+
+// has collects the error if the key isn't set or can't be resolved
+func (ch *Checker) has(key string) bool {
+	if err := requireKey(ch.p, key); err != nil {
+		ch.errs = append(ch.errs, err)
+		return false
+	}
+	return true
+}
+
+func checkValue[T any](ch *Checker, key string, get func(Provider, string) T) T {
+	var v T
+	if ch.has(key) {
+		v = get(ch.p, key)
+	}
+	return v
+}
+
+func checkParse[T any](ch *Checker, key string, get func(Provider, string) (T, error)) T {
+	var v T
+	if ch.has(key) {
+		var err error
+		if v, err = get(ch.p, key); err != nil {
+			ch.errs = append(ch.errs, &KeyError{Key: key, Err: err})
+		}
+	}
+	return v
+}
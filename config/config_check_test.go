@@ -0,0 +1,82 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequire(t *testing.T) {
+	p, _ := NewConfigData("ini", []byte("[db]\nhost = localhost\nport = 80a\ntimeout = 5s\n"))
+	if err := p.Require("db.host", "db.port"); err != nil {
+		t.Fatal(err)
+	}
+	err := p.Require("db.host", "db.user", "db.password")
+	errs, ok := err.(KeyErrors)
+	if !ok || len(errs) != 2 || errs[0].Key != "db.user" || errs[1].Key != "db.password" {
+		t.Fatalf("db.user and db.password should be missing, got %v", err)
+	}
+
+	// test Must*
+	if v := p.MustString("db.host"); v != "localhost" {
+		t.Fatalf("db.host is %s", v)
+	}
+	if v := p.MustDuration("db.timeout"); v != 5*time.Second {
+		t.Fatalf("db.timeout is %v", v)
+	}
+	mustPanic := func(name string, f func()) {
+		defer func() {
+			r := recover()
+			if _, ok := r.(*KeyError); !ok {
+				t.Fatalf("%s should panic with *KeyError, got %v", name, r)
+			}
+		}()
+		f()
+	}
+	mustPanic("MustString", func() { p.MustString("db.user") })
+	mustPanic("MustInt", func() { p.MustInt("db.port") })
+	mustPanic("MustInt64", func() { p.MustInt64("db.port") })
+	mustPanic("MustBool", func() { p.MustBool("db.port") })
+	mustPanic("MustFloat", func() { p.MustFloat("db.port") })
+	mustPanic("MustDuration", func() { p.MustDuration("db.user") })
+
+	// test Checker
+	ch := Check(p)
+	host := ch.String("db.host")
+	port := ch.Int("db.port")
+	timeout := ch.Duration("db.timeout")
+	ch.Strings("db.user")
+	ch.Int64("db.port")
+	ch.Bool("db.host")
+	ch.Float("db.host")
+	ch.Bytes("db.host")
+	retries := CheckAs[int](ch, "db.retries")
+	if host != "localhost" || port != 0 || timeout != 5*time.Second || retries != 0 {
+		t.Fatal("checker values error")
+	}
+	err = ch.Err()
+	if !errors.As(err, &errs) || len(errs) != 7 {
+		t.Fatalf("checker should report 7 keys, got %v", err)
+	}
+	if !errors.Is(errs[1], ErrKeyNotFound) || !strings.Contains(errs[0].Error(), "db.port") {
+		t.Fatalf("checker errors are %v", errs)
+	}
+	if err := Check(p).Err(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	src, err := NewConfig("ini", configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, adapterName := range []string{"ini", "json", "yaml", "toml"} {
+		data, err := Convert(src, adapterName)
+		if err != nil {
+			t.Fatalf("convert to %s: %v", adapterName, err)
+		}
+		dst, err := NewConfigData(adapterName, data)
+		if err != nil {
+			t.Fatalf("parse %s: %v", adapterName, err)
+		}
+		// convert back
+		data, err = Convert(dst, "ini")
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := NewConfigData("ini", data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, got := src.Document(), back.Document()
+		if adapterName == "json" {
+			stripComments(want)
+			stripComments(got)
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Fatalf("%s round trip changed the document", adapterName)
+		}
+	}
+	if _, err := Convert(src, "aaa"); err == nil {
+		t.Fatal("adapter aaa shouldn't exist")
+	}
+
+	// test key of default section conflicting with section
+	conflict, err := NewConfigData("ini", []byte("db = local\n[db]\nhost = localhost\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, adapterName := range []string{"json", "yaml", "toml"} {
+		if _, err := Convert(conflict, adapterName); err == nil {
+			t.Fatalf("%s: key db conflicts with section db", adapterName)
+		}
+	}
+
+	// test numbers and bools keep their types
+	typed, err := NewConfigData("ini", []byte("port = 5432\nssl = true\nratio = -0.5e3\nzip = 0123\nbig = 99999999999999999999\nname = app\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for adapterName, assign := range map[string]string{"json": `"%s": %s`, "yaml": "%s: %s", "toml": "%s = %s"} {
+		data, err := Convert(typed, adapterName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, value := range map[string]string{"port": "5432", "ssl": "true", "ratio": "-0.5e3",
+			"zip": `"0123"`, "big": `"99999999999999999999"`, "name": `"app"`} {
+			if want := fmt.Sprintf(assign, key, value); !strings.Contains(string(data), want) {
+				t.Fatalf("%s doesn't contain %s:\n%s", adapterName, want, data)
+			}
+		}
+		back, err := NewConfigData(adapterName, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back.Get("port") != "5432" || back.Get("zip") != "0123" || back.Get("ratio") != "-0.5e3" {
+			t.Fatalf("%s round trip is %v", adapterName, back.Document())
+		}
+	}
+}
+
+func TestConvertFormats(t *testing.T) {
+	cases := map[string]string{
+		"json": `{"name": "app", "db": {"host": "localhost", "port": 3306, "pool": {"size": 10}, "tags": ["a", "b"]}}`,
+		"yaml": "# app name\nname: app\ndb:\n  host: 'localhost'\n  port: 3306 # mysql\n  pool:\n    size: 10\n  tags: [a, \"b\"]\n",
+		"toml": "name = \"app\"\n[db]\nhost = 'localhost'\nport = 3306 # mysql\npool.size = 10\ntags = [\"a\", \"b\"]\n",
+	}
+	for adapterName, data := range cases {
+		c, err := NewConfigData(adapterName, []byte(data))
+		if err != nil {
+			t.Fatalf("parse %s: %v", adapterName, err)
+		}
+		if v := c.Get("name"); v != "app" {
+			t.Fatalf("%s: name is %q", adapterName, v)
+		}
+		if v := c.Get("db.host"); v != "localhost" {
+			t.Fatalf("%s: db.host is %q", adapterName, v)
+		}
+		if v, _ := c.Int("db.port"); v != 3306 {
+			t.Fatalf("%s: db.port is %d", adapterName, v)
+		}
+		if v, _ := c.Int("db.pool.size"); v != 10 {
+			t.Fatalf("%s: db.pool.size is %d", adapterName, v)
+		}
+		if v := c.Strings("db.tags"); !reflect.DeepEqual(v, []string{"a", "b"}) {
+			t.Fatalf("%s: db.tags is %v", adapterName, v)
+		}
+	}
+	// test commas in quoted items of flow sequences
+	for adapterName, data := range map[string]string{
+		"yaml": "tags: [\"x,y\", z, 'a,b', don't]\n",
+		"toml": "tags = [\"x,y\", 'z', \"a,b\", \"don't\"]\n",
+	} {
+		c, err := NewConfigData(adapterName, []byte(data))
+		if err != nil {
+			t.Fatalf("parse %s: %v", adapterName, err)
+		}
+		if v := c.Get("tags"); v != "x,y;z;a,b;don't" {
+			t.Fatalf("%s: tags is %q", adapterName, v)
+		}
+	}
+	// test key without children is null in yaml
+	c, err := NewConfigData("yaml", []byte("# app name\nname:\ndb:\n  host: localhost\n  pool:\nempty: {}\nport: 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Has("name") || c.Get("name") != "" || !c.Has("db.pool") || c.Get("port") != "1" {
+		t.Fatalf("yaml with null is %v", c.Document())
+	}
+	if _, err := c.GetSection("name"); err == nil {
+		t.Fatal("name shouldn't be a section")
+	}
+	if _, err := c.GetSection("empty"); err != nil {
+		t.Fatal("empty should be a section")
+	}
+	if s := c.Document().Sections[0]; s.Name != defaultSection || s.Entries[0].Comment != " app name" {
+		t.Fatalf("default section is %+v", s)
+	}
+	if _, err := NewConfigData("yaml", []byte("list:\n  - a\n")); err == nil {
+		t.Fatal("block sequence shouldn't be supported")
+	}
+	if _, err := NewConfigData("toml", []byte("[[list]]\n")); err == nil {
+		t.Fatal("array of tables shouldn't be supported")
+	}
+}
+
+func stripComments(doc *Document) {
+	for i := range doc.Sections {
+		doc.Sections[i].Comment = ""
+		for j := range doc.Sections[i].Entries {
+			doc.Sections[i].Entries[j].Comment = ""
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	// sourceSet is the source of keys written by Set
+	sourceSet = "set"
+	// sourceDefault is the source of keys written by the defaults of BindStruct
+	sourceDefault = "default"
+	// redactedValue replaces the value of redacted keys
+	redactedValue = "******"
+)
+
+// DefaultRedactPatterns are the key patterns redacted by Handler when none is given
+var DefaultRedactPatterns = []string{"*password*", "*secret*", "*token*"}
+
+// Setting is an effective value of config and where it comes from
+type Setting struct {
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Source  string `json:"source,omitempty"`
+}
+
+// Settings lists the effective raw values of provider,
+// the values of parent are overwritten by the same keys of children.
+// The source is the file name which the value is loaded from,
+// "set" for values written by Set, "env NAME" for values applied by ApplyEnv,
+// and "default" for the defaults of BindStruct.
+func Settings(p Provider) []Setting {
+	// layers from the root parent to p
+	var layers []Provider
+	for layer := p; layer != nil; {
+		layers = append([]Provider{layer}, layers...)
+		c, ok := layer.(*Container)
+		if !ok {
+			break
+		}
+		c.RLock()
+		layer = c.parent
+		c.RUnlock()
+	}
+
+	var settings []Setting
+	index := make(map[string]int)
+	for _, layer := range layers {
+		c, _ := layer.(*Container)
+		for _, section := range layer.Document().Sections {
+			for _, entry := range section.Entries {
+				s := Setting{Section: section.Name, Key: entry.Key, Value: entry.Value}
+				if c != nil {
+					s.Source = c.sourceOf(section.Name, entry.Key)
+				}
+				full := section.Name + attributeDivision + entry.Key
+				if i, ok := index[full]; ok {
+					settings[i] = s
+					continue
+				}
+				index[full] = len(settings)
+				settings = append(settings, s)
+			}
+		}
+	}
+	return settings
+}
+
+// Redact replaces the values whose key matches any pattern by "******",
+// patterns are matched by path.Match against "section.key" in lower case.
+func Redact(settings []Setting, patterns ...string) []Setting {
+	redacted := make([]Setting, len(settings))
+	for i, s := range settings {
+		full := strings.ToLower(s.Section + attributeDivision + s.Key)
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(strings.ToLower(pattern), full); ok {
+				s.Value = redactedValue
+				break
+			}
+		}
+		redacted[i] = s
+	}
+	return redacted
+}
+
+// Handler renders the effective config, mount it like "/debug/config".
+// The output is json by default, ini when the query is "?format=ini",
+// in which the source of each value is written as comment.
+type Handler struct {
+	provider Provider
+	patterns []string
+}
+
+// NewHandler returns a handler rendering p, the keys matching patterns are redacted,
+// DefaultRedactPatterns are used when patterns is empty.
+func NewHandler(p Provider, patterns ...string) *Handler {
+	if len(patterns) == 0 {
+		patterns = DefaultRedactPatterns
+	}
+	return &Handler{provider: p, patterns: patterns}
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	settings := Redact(Settings(h.provider), h.patterns...)
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(settings)
+	case "ini":
+		data, err := new(IniConfig).Marshal(settingsDocument(settings))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write(data)
+	default:
+		http.Error(w, "unknown format "+r.URL.Query().Get("format"), http.StatusBadRequest)
+	}
+}
+
+// settingsDocument converts settings to document, sources become comments
+func settingsDocument(settings []Setting) *Document {
+	doc := new(Document)
+	index := make(map[string]int)
+	for _, s := range settings {
+		i, ok := index[s.Section]
+		if !ok {
+			i = len(doc.Sections)
+			index[s.Section] = i
+			doc.Sections = append(doc.Sections, Section{Name: s.Section})
+		}
+		entry := Entry{Key: s.Key, Value: s.Value}
+		if s.Source != "" {
+			entry.Comment = " source: " + s.Source
+		}
+		doc.Sections[i].Entries = append(doc.Sections[i].Entries, entry)
+	}
+	return doc
+}
+
+// withSource records the source of provider parsed from file
+func withSource(p Provider, err error, source string) (Provider, error) {
+	if c, ok := p.(*Container); ok {
+		c.Lock()
+		c.source = source
+		c.Unlock()
+	}
+	return p, err
+}
+
+// setSource records the source of key
+func (c *Container) setSource(key, source string) {
+	c.Lock()
+	defer c.Unlock()
+	section, k := c.parseSectionKey(key)
+	c.sources[section+attributeDivision+k] = source
+}
+
+// sourceOf retrieves where the key is loaded from
+func (c *Container) sourceOf(section, key string) string {
+	c.RLock()
+	defer c.RUnlock()
+	if source, ok := c.sources[section+attributeDivision+key]; ok {
+		return source
+	}
+	return c.source
+}
@@ -0,0 +1,94 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHandler(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app/config.ini":       {Data: []byte("name = app\n[db]\nhost = localhost\npassword = root\n")},
+		"app/admin/config.ini": {Data: []byte("[db]\nhost = 10.0.0.1\napi_token = abc\n")},
+	}
+	app, err := LoadAppFS(fsys, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin := app.Module("admin")
+	admin.Set("debug", "on")
+	t.Setenv("ADMIN_NAME", "admin")
+	if err := ApplyEnv(admin, "admin", "name"); err != nil {
+		t.Fatal(err)
+	}
+
+	// test settings of layers
+	want := []Setting{
+		{Section: "common", Key: "name", Value: "admin", Source: "env ADMIN_NAME"},
+		{Section: "db", Key: "host", Value: "10.0.0.1", Source: "app/admin/config.ini"},
+		{Section: "db", Key: "password", Value: "******", Source: "app/config.ini"},
+		{Section: "db", Key: "api_token", Value: "******", Source: "app/admin/config.ini"},
+		{Section: "common", Key: "debug", Value: "on", Source: "set"},
+	}
+	got := Redact(Settings(admin), DefaultRedactPatterns...)
+	if len(got) != len(want) {
+		t.Fatalf("settings are %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("setting %d is %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// test json
+	h := NewHandler(admin)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+	var settings []Setting
+	if err := json.Unmarshal(rec.Body.Bytes(), &settings); err != nil || len(settings) != len(want) || settings[2] != want[2] {
+		t.Fatalf("json is %s %v", rec.Body, err)
+	}
+	if strings.Contains(rec.Body.String(), "root") {
+		t.Fatal("password isn't redacted")
+	}
+
+	// test ini
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/config?format=ini", nil))
+	if body := rec.Body.String(); !strings.Contains(body, "# source: app/admin/config.ini\nhost=10.0.0.1") ||
+		!strings.Contains(body, "password=******") {
+		t.Fatalf("ini is %s", body)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/config?format=xml", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("xml is %d", rec.Code)
+	}
+
+	// test custom patterns and LoadDir sources
+	p, err := LoadDirFS(fstest.MapFS{"conf/cache.ini": {Data: []byte("host = redis\n")}}, "conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = Redact(Settings(p), "cache.*")
+	if len(got) != 1 || got[0].Value != "******" || got[0].Source != "conf/cache.ini" {
+		t.Fatalf("settings are %v", got)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// FileError records the error of one file
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return e.File + ": " + e.Err.Error()
+}
+
+// DirError lists the errors of files which failed in LoadDir
+type DirError struct {
+	Dir    string
+	Errors []*FileError
+}
+
+func (e *DirError) Error() string {
+	msg := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msg = append(msg, err.Error())
+	}
+	return fmt.Sprintf("load dir %s: %s", e.Dir, strings.Join(msg, "; "))
+}
+
+// LoadDir parses every file in dir whose extension is claimed by a registered adapter,
+// like database.ini, cache.yml or the template app.ini.tmpl, the other files are skipped.
+// The base file name is the section of its keys, so host in [master] of database.ini
+// is database.master.host, and keys of default section are database.host.
+// Files are loaded in name order, keys of later file overwrite the same keys of previous.
+// When some files fail, the provider of the other files is returned with *DirError.
+func LoadDir(dir string) (Provider, error) {
+	return loadDir(os.DirFS(dir), ".", dir, "")
+}
+
+// LoadDirWith is like LoadDir, but parses every file in dir by adapterName.
+func LoadDirWith(dir, adapterName string) (Provider, error) {
+	if _, ok := adapters[adapterName]; !ok {
+		return nil, fmt.Errorf("load dir: unknown adapter %s, register it first please", adapterName)
+	}
+	return loadDir(os.DirFS(dir), ".", dir, adapterName)
+}
+
+// LoadDirFS is like LoadDir, but reads dir in fsys.
+func LoadDirFS(fsys fs.FS, dir string) (Provider, error) {
+	return loadDir(fsys, dir, dir, "")
+}
+
+func loadDir(fsys fs.FS, dir, dirName, adapterName string) (Provider, error) {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	c := newContainer()
+	dirErr := &DirError{Dir: dirName}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		adapter := adapterName
+		if adapter == "" {
+			var ok bool
+			if adapter, ok = AdapterByExtension(name); !ok {
+				continue
+			}
+		}
+		p, err := NewConfigFS(adapter, fsys, path.Join(dir, name))
+		if err != nil {
+			dirErr.Errors = append(dirErr.Errors, &FileError{File: name, Err: err})
+			continue
+		}
+		scope := trimTemplateExt(name)
+		c.merge(strings.ToLower(strings.TrimSuffix(scope, path.Ext(scope))), path.Join(dirName, name), p.Document())
+	}
+	if len(dirErr.Errors) > 0 {
+		return c, dirErr
+	}
+	return c, nil
+}
+
+// merge writes the document into scope section,
+// section names of document become the prefix of keys.
+func (c *Container) merge(scope, source string, doc *Document) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.addSection(scope)
+	for _, section := range doc.Sections {
+		prefix := ""
+		if section.Name != defaultSection {
+			prefix = section.Name + attributeDivision
+		}
+		for i, entry := range section.Entries {
+			key := prefix + entry.Key
+			c.setValue(scope, key, entry.Value)
+			c.sources[scope+attributeDivision+key] = source
+			comment := entry.Comment
+			// keep the section comment on its first key
+			if i == 0 && section.Comment != "" {
+				if comment == "" {
+					comment = section.Comment
+				} else {
+					comment = section.Comment + lineBreak + comment
+				}
+			}
+			if comment != "" {
+				c.attributeComment[scope+attributeDivision+key] = comment
+			}
+		}
+	}
+}
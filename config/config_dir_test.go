@@ -0,0 +1,78 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config-dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"database.ini": "type = mysql\n[master]\nhost = 10.0.0.1\n",
+		"cache.json":   `{"driver": "redis", "redis": {"port": 6379}}`,
+		"readme.txt":   "not a config",
+		"broken.ini":   "a = b = c\n",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := LoadDir(dir)
+	dirErr, ok := err.(*DirError)
+	if !ok || len(dirErr.Errors) != 1 || dirErr.Errors[0].File != "broken.ini" {
+		t.Fatalf("broken.ini should be reported, got %v", err)
+	}
+	if v := p.Get("database.type"); v != "mysql" {
+		t.Fatalf("database.type is %s", v)
+	}
+	if v := p.Get("database.master.host"); v != "10.0.0.1" {
+		t.Fatalf("database.master.host is %s", v)
+	}
+	if v, _ := p.Int("cache.redis.port"); v != 6379 {
+		t.Fatalf("cache.redis.port is %d", v)
+	}
+	// sections are sorted by file name
+	if keys := Keys(p); !reflect.DeepEqual(keys, []string{"cache.driver", "cache.redis.port", "database.type", "database.master.host"}) {
+		t.Fatalf("keys are %v", keys)
+	}
+
+	// test LoadDirWith
+	os.Remove(filepath.Join(dir, "broken.ini"))
+	os.Remove(filepath.Join(dir, "cache.json"))
+	p, err = LoadDirWith(dir, "ini")
+	if err != nil || p.Get("database.type") != "mysql" {
+		t.Fatal(err)
+	}
+	if _, err := p.GetSection("readme"); err != nil {
+		t.Fatal("readme.txt should be parsed as ini")
+	}
+	if _, err = LoadDirWith(dir, "aaa"); err == nil {
+		t.Fatal("adapter aaa shouldn't exist")
+	}
+	if _, err = LoadDir(filepath.Join(dir, "aaa")); err == nil {
+		t.Fatal("dir aaa shouldn't exist")
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// configFlag is a flag.Value which reads and writes the config key
+type configFlag struct {
+	p      Provider
+	key    string
+	isBool bool
+}
+
+func (f *configFlag) String() string {
+	if f.p == nil {
+		return ""
+	}
+	return rawValue(f.p, f.key)
+}
+
+func (f *configFlag) Set(value string) error {
+	return f.p.Set(f.key, value)
+}
+
+// IsBoolFlag allows "--debug" without value for bool keys
+func (f *configFlag) IsBoolFlag() bool {
+	return f.isBool
+}
+
+// Keys retrieves all keys of the provider in original sort,
+// keys of default section have no section prefix.
+func Keys(p Provider) []string {
+	var keys []string
+	division := sectionDivision
+	if c, ok := p.(*Container); ok {
+		c.RLock()
+		division, _ = c.divisions()
+		c.RUnlock()
+	}
+	for _, section := range p.Document().Sections {
+		for _, entry := range section.Entries {
+			if section.Name == defaultSection {
+				keys = append(keys, entry.Key)
+			} else {
+				keys = append(keys, section.Name+division+entry.Key)
+			}
+		}
+	}
+	return keys
+}
+
+// EnvName retrieves the environment variable name of key,
+// db.port with prefix APP is APP_DB_PORT.
+func EnvName(prefix, key string) string {
+	name := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+	if prefix == "" {
+		return name
+	}
+	return strings.ToUpper(prefix) + "_" + name
+}
+
+// ApplyEnv overrides keys of the provider by environment variables named by EnvName.
+// To get the precedence flags > env > file, call it after parsing file
+// and before parsing flags bound by BindFlags.
+func ApplyEnv(p Provider, prefix string, keys ...string) error {
+	if len(keys) == 0 {
+		keys = Keys(p)
+	}
+	for _, key := range keys {
+		name := EnvName(prefix, key)
+		if value, ok := os.LookupEnv(name); ok {
+			if err := p.Set(key, value); err != nil {
+				return err
+			}
+			if c, ok := p.(*Container); ok {
+				c.setSource(key, "env "+name)
+			}
+		}
+	}
+	return nil
+}
+
+// BindFlags defines a flag named by each key on fs, "--db.port=5433" writes db.port.
+// The default value listed by "--help" is the current value of key as written in source,
+// so the encrypted and file referenced secrets aren't revealed,
+// all keys of the provider are bound when keys is empty.
+func BindFlags(fs *flag.FlagSet, p Provider, keys ...string) {
+	if len(keys) == 0 {
+		keys = Keys(p)
+	}
+	for _, key := range keys {
+		bindFlag(fs, p, key, "", isBoolValue(p.Get(key)))
+	}
+}
+
+// BindStruct defines flags by the fields of struct pointed by v,
+// which are tagged like `config:"db.port" usage:"database port"`.
+// A nested struct tagged `config:"db"` prefixes the keys of its fields.
+// The field value is written into provider as default when the key isn't set,
+// and the flags of bool fields can be given without value like "--debug".
+func BindStruct(fs *flag.FlagSet, p Provider, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind struct: %T isn't a struct pointer", v)
+	}
+	return bindStruct(fs, p, rv.Elem(), "")
+}
+
+func bindStruct(fs *flag.FlagSet, p Provider, rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		key := field.Tag.Get("config")
+		if key == "" || key == "-" || field.PkgPath != "" {
+			continue
+		}
+		key = prefix + key
+		if field.Type.Kind() == reflect.Struct {
+			if err := bindStruct(fs, p, rv.Field(i), key+attributeDivision); err != nil {
+				return err
+			}
+			continue
+		}
+		if !p.Has(key) {
+			if err := p.Set(key, fmt.Sprint(rv.Field(i).Interface())); err != nil {
+				return err
+			}
+			if c, ok := p.(*Container); ok {
+				c.setSource(key, sourceDefault)
+			}
+		}
+		bindFlag(fs, p, key, field.Tag.Get("usage"), field.Type.Kind() == reflect.Bool)
+	}
+	return nil
+}
+
+// bindFlag defines the flag of key
+func bindFlag(fs *flag.FlagSet, p Provider, key, usage string, isBool bool) {
+	name := key
+	if c, ok := p.(*Container); !ok || c.caseMode != CaseSensitive {
+		name = strings.ToLower(key)
+	}
+	if usage == "" {
+		usage = "set config " + name
+	}
+	fs.Var(&configFlag{p: p, key: key, isBool: isBool}, name, usage)
+	// show the current value in "--help"
+	fs.Lookup(name).DefValue = rawValue(p, key)
+}
+
+// rawValue retrieves the value of key as written in source, the secrets aren't resolved
+func rawValue(p Provider, key string) string {
+	if c, ok := p.(*Container); ok {
+		value, _ := c.lookup(key)
+		return value
+	}
+	return p.Get(key)
+}
+
+// isBoolValue reports whether value is literally bool, so "1" and "0" of numbers aren't
+func isBoolValue(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "false", "on", "off":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	p, err := NewConfigData("ini", []byte("debug = off\nworkers = 1\n[db]\nhost = localhost\nport = 5432\nuser = root\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// test ApplyEnv
+	os.Setenv("APP_DB_HOST", "db.local")
+	os.Setenv("APP_DB_PORT", "5434")
+	defer os.Unsetenv("APP_DB_HOST")
+	defer os.Unsetenv("APP_DB_PORT")
+	if err := ApplyEnv(p, "app"); err != nil {
+		t.Fatal(err)
+	}
+	if v := p.Get("db.host"); v != "db.local" {
+		t.Fatalf("db.host is %s", v)
+	}
+	// test BindFlags, flags take precedence over env
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	BindFlags(fs, p)
+	if err := fs.Parse([]string{"--db.port=5433", "--debug", "-db.user", "admin", "--workers", "4", "arg"}); err != nil {
+		t.Fatal(err)
+	}
+	// test numeric 0/1 isn't bool flag
+	if v := p.Get("workers"); v != "4" || fs.NArg() != 1 {
+		t.Fatalf("workers is %s, args are %v", v, fs.Args())
+	}
+	if v, _ := p.Int("db.port"); v != 5433 {
+		t.Fatalf("db.port is %d", v)
+	}
+	if v, _ := p.Bool("debug"); !v {
+		t.Fatal("debug should be on")
+	}
+	if v := p.Get("db.user"); v != "admin" {
+		t.Fatalf("db.user is %s", v)
+	}
+	// test help lists current values
+	var help bytes.Buffer
+	fs.SetOutput(&help)
+	fs.PrintDefaults()
+	if !strings.Contains(help.String(), "-db.host") || !strings.Contains(help.String(), "(default db.local)") {
+		t.Fatalf("help is %s", help.String())
+	}
+
+	// test BindStruct
+	var opts struct {
+		Name    string `config:"name" usage:"app name"`
+		Verbose bool   `config:"verbose"`
+		DB      struct {
+			Host    string `config:"host"`
+			Timeout int    `config:"timeout"`
+		} `config:"db"`
+		skip string
+	}
+	opts.Name = "readygo"
+	opts.DB.Timeout = 30
+	fs = flag.NewFlagSet("app", flag.ContinueOnError)
+	if err := BindStruct(fs, p, &opts); err != nil {
+		t.Fatal(err)
+	}
+	if f := fs.Lookup("db.timeout"); f == nil || f.DefValue != "30" {
+		t.Fatal("db.timeout should default to the field value")
+	}
+	if f := fs.Lookup("db.host"); f == nil || f.DefValue != "db.local" {
+		t.Fatal("db.host should default to the config value")
+	}
+	if err := fs.Parse([]string{"--name", "demo", "--verbose"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := p.Get("name"); v != "demo" {
+		t.Fatalf("name is %s", v)
+	}
+	if v, _ := p.Bool("verbose"); !v {
+		t.Fatal("verbose should be on")
+	}
+	// test defaults are written into provider
+	if v := p.Get("db.timeout"); v != "30" {
+		t.Fatalf("db.timeout is %q", v)
+	}
+	if s := Settings(p); s[len(s)-1].Key != "timeout" || s[len(s)-1].Source != sourceDefault {
+		t.Fatalf("source of db.timeout is %v", s[len(s)-1])
+	}
+	if err := BindStruct(fs, p, opts); err == nil {
+		t.Fatal("struct value shouldn't be bound")
+	}
+}
+
+func TestFlagSecret(t *testing.T) {
+	defer func() { secretKey = nil }()
+	defer SetSecretDir("/run/secrets")
+	key, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey = nil
+	t.Setenv(SecretKeyEnv, key)
+	encrypted, err := Encrypt("hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	SetSecretDir(dir)
+	ioutil.WriteFile(filepath.Join(dir, "token"), []byte("s3cr3t\n"), 0600)
+	p, err := NewConfigData("ini", []byte("[db]\npassword = "+encrypted+"\ntoken = @file:token\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Get("db.password") != "hunter2" || p.Get("db.token") != "s3cr3t" {
+		t.Fatal("secrets should be resolved")
+	}
+
+	// test help never shows the resolved secrets
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	var help bytes.Buffer
+	fs.SetOutput(&help)
+	BindFlags(fs, p)
+	fs.PrintDefaults()
+	if strings.Contains(help.String(), "hunter2") || strings.Contains(help.String(), "s3cr3t") {
+		t.Fatalf("help reveals secrets: %s", help.String())
+	}
+	if !strings.Contains(help.String(), "(default @file:token)") {
+		t.Fatalf("help is %s", help.String())
+	}
+	if v := fs.Lookup("db.password").Value.String(); v != encrypted {
+		t.Fatalf("flag value is %s", v)
+	}
+}
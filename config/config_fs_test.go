@@ -0,0 +1,69 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"embed"
+	"testing"
+	"testing/fstest"
+)
+
+//go:embed test_files/php.ini
+var embedFiles embed.FS
+
+func TestFS(t *testing.T) {
+	// test NewConfigFS with embed.FS
+	p, err := NewConfigFS("ini", embedFiles, "test_files/php.ini")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := p.Bool("php.engine"); !v {
+		t.Fatal("php.engine should be on")
+	}
+	if _, err := NewConfigFS("ini", embedFiles, "test_files/aaa.ini"); err == nil {
+		t.Fatal("aaa.ini shouldn't exist")
+	}
+	if _, err := NewConfigFS("aaa", embedFiles, "test_files/php.ini"); err == nil {
+		t.Fatal("adapter aaa shouldn't exist")
+	}
+
+	fsys := fstest.MapFS{
+		"app/config.toml":          {Data: []byte("[db]\nhost = \"localhost\"\n")},
+		"app/admin/config.yaml":    {Data: []byte("db:\n  host: admin.local\n")},
+		"app/conf.d/cache.json":    {Data: []byte(`{"driver": "redis"}`)},
+		"app/conf.d/database.toml": {Data: []byte("[master]\nhost = \"10.0.0.1\"\n")},
+	}
+	for _, adapterName := range []string{"json", "yaml", "toml"} {
+		if _, err := NewConfigFS(adapterName, fsys, "app/aaa"); err == nil {
+			t.Fatalf("%s: app/aaa shouldn't exist", adapterName)
+		}
+	}
+	// test LoadDirFS
+	p, err = LoadDirFS(fsys, "app/conf.d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Get("cache.driver") != "redis" || p.Get("database.master.host") != "10.0.0.1" {
+		t.Fatal("load dir from fs error")
+	}
+	// test LoadAppFS
+	app, err := LoadAppFS(fsys, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Global().Get("db.host") != "localhost" || app.Module("admin").Get("db.host") != "admin.local" {
+		t.Fatal("load app from fs error")
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrKeyNotFound is the error of KeyError when the key isn't set
+var ErrKeyNotFound = errors.New("key not find")
+
+// KeyError records the key whose value is missing or can't be parsed
+type KeyError struct {
+	Key string
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return "config key " + e.Key + ": " + e.Err.Error()
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// KeyErrors lists the errors of every missing or unparsable key
+type KeyErrors []*KeyError
+
+func (e KeyErrors) Error() string {
+	msg := make([]string, 0, len(e))
+	for _, err := range e {
+		msg = append(msg, err.Error())
+	}
+	return strings.Join(msg, "; ")
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Get retrieves the value of key as T, which can be string, bool, any int, uint and float,
+// time.Duration, time.Time, a type implementing encoding.TextUnmarshaler by pointer,
+// slices of them split by list separator and maps of them keyed by string.
+// The error is *KeyError when the key isn't set, can't be resolved or its value can't be parsed.
+func Get[T any](p Provider, key string) (T, error) {
+	var v T
+	if err := requireKey(p, key); err != nil {
+		return v, err
+	}
+	if err := decode(p, key, reflect.ValueOf(&v).Elem()); err != nil {
+		return v, &KeyError{Key: key, Err: err}
+	}
+	return v, nil
+}
+
+// GetOr retrieves the value of key as T like Get,
+// defaultVal is returned when the key isn't set or its value can't be parsed.
+func GetOr[T any](p Provider, key string, defaultVal T) T {
+	v, err := Get[T](p, key)
+	if err != nil {
+		return defaultVal
+	}
+	return v
+}
+
+// requireKey returns *KeyError when the key isn't set or can't be resolved, like the secret can't be decrypted
+func requireKey(p Provider, key string) *KeyError {
+	err := p.Require(key)
+	if err == nil {
+		return nil
+	}
+	if errs, ok := err.(KeyErrors); ok && len(errs) == 1 {
+		return errs[0]
+	}
+	return &KeyError{Key: key, Err: err}
+}
+
+// decode sets rv by the value of key
+func decode(p Provider, key string, rv reflect.Value) error {
+	if isScalar(rv) {
+		return decodeScalar(p.Get(key), rv)
+	}
+	switch rv.Kind() {
+	case reflect.Slice:
+		items := p.Strings(key)
+		slice := reflect.MakeSlice(rv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeScalar(item, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+		return nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		items, err := p.StringMap(key)
+		if err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(rv.Type(), len(items))
+		for k, item := range items {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := decodeScalar(item, elem); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), elem)
+		}
+		rv.Set(m)
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", rv.Type())
+}
+
+// isScalar reports whether rv is decoded from a single value
+func isScalar(rv reflect.Value) bool {
+	if rv.Type() == timeType || reflect.PtrTo(rv.Type()).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// decodeScalar sets rv by value
+func decodeScalar(value string, rv reflect.Value) error {
+	// time.Time implements encoding.TextUnmarshaler by RFC3339 only
+	switch rv.Type() {
+	case durationType:
+		d, err := ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	case timeType:
+		t, err := ParseTime(value)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if u, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Bool:
+		b, err := ParseBool(value)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", rv.Type())
+	}
+	return nil
+}
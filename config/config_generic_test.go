@@ -0,0 +1,114 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "debug":
+		*l = 1
+	case "info":
+		*l = 2
+	default:
+		return errors.New("unknown level " + string(text))
+	}
+	return nil
+}
+
+func TestGeneric(t *testing.T) {
+	c := newContainer()
+	c.Set("name", "readygo")
+	c.Set("debug", "on")
+	c.Set("port", "8080")
+	c.Set("small", "300")
+	c.Set("ratio", "0.5")
+	c.Set("timeout", "1m")
+	c.Set("start", "2026-01-01")
+	c.Set("ip", "10.0.0.1")
+	c.Set("level", "info")
+	c.Set("ports", "80;443")
+	c.Set("timeouts", "1s;2s")
+	c.Set("weights", "a:1,b:2")
+
+	if v, err := Get[string](c, "name"); err != nil || v != "readygo" {
+		t.Fatalf("name is %v %v", v, err)
+	}
+	if v, err := Get[bool](c, "debug"); err != nil || !v {
+		t.Fatalf("debug is %v %v", v, err)
+	}
+	if v, err := Get[uint16](c, "port"); err != nil || v != 8080 {
+		t.Fatalf("port is %v %v", v, err)
+	}
+	if v, err := Get[float32](c, "ratio"); err != nil || v != 0.5 {
+		t.Fatalf("ratio is %v %v", v, err)
+	}
+	if v, err := Get[time.Duration](c, "timeout"); err != nil || v != time.Minute {
+		t.Fatalf("timeout is %v %v", v, err)
+	}
+	if v, err := Get[time.Time](c, "start"); err != nil || v.Year() != 2026 {
+		t.Fatalf("start is %v %v", v, err)
+	}
+	if v, err := Get[net.IP](c, "ip"); err != nil || v.String() != "10.0.0.1" {
+		t.Fatalf("ip is %v %v", v, err)
+	}
+	if v, err := Get[level](c, "level"); err != nil || v != 2 {
+		t.Fatalf("level is %v %v", v, err)
+	}
+	if v, err := Get[[]int](c, "ports"); err != nil || !reflect.DeepEqual(v, []int{80, 443}) {
+		t.Fatalf("ports is %v %v", v, err)
+	}
+	if v, err := Get[[]time.Duration](c, "timeouts"); err != nil || !reflect.DeepEqual(v, []time.Duration{time.Second, 2 * time.Second}) {
+		t.Fatalf("timeouts is %v %v", v, err)
+	}
+	if v, err := Get[map[string]int](c, "weights"); err != nil || !reflect.DeepEqual(v, map[string]int{"a": 1, "b": 2}) {
+		t.Fatalf("weights is %v %v", v, err)
+	}
+
+	// test errors
+	_, err := Get[int](c, "aaa")
+	var keyErr *KeyError
+	if !errors.As(err, &keyErr) || keyErr.Key != "aaa" || !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("aaa should be not found, got %v", err)
+	}
+	if _, err := Get[int8](c, "small"); !errors.As(err, &keyErr) || errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("300 overflows int8, got %v", err)
+	}
+	if _, err := Get[struct{}](c, "name"); err == nil {
+		t.Fatal("struct isn't supported")
+	}
+	if _, err := Get[map[int]int](c, "weights"); err == nil {
+		t.Fatal("map keyed by int isn't supported")
+	}
+
+	// test GetOr
+	if v := GetOr(c, "aaa", 3306); v != 3306 {
+		t.Fatalf("GetOr is %d", v)
+	}
+	if v := GetOr(c, "name", 1); v != 1 {
+		t.Fatalf("GetOr is %d", v)
+	}
+	if v := GetOr(c, "port", 1); v != 8080 {
+		t.Fatalf("GetOr is %d", v)
+	}
+}
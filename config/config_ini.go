@@ -15,21 +15,26 @@
 package config
 
 import (
-	"bufio"
 	"bytes"
 	"container/list"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
+// DefaultSection holds the keys which don't belong to any section
+const DefaultSection = "common"
+
 var (
-	defaultSection   = "common"
+	defaultSection   = DefaultSection
 	byteEmpty        = []byte{}
 	byteWellNumber   = []byte{'#'} // comment
 	byteSemicolon    = []byte{';'} // comment
@@ -39,52 +44,147 @@ var (
 	byteSectionEnd   = []byte{']'} // section end sign
 )
 
-var (
-	sectionDivision   = "."
+var iniKey = regexp.MustCompile(`^[A-Za-z0-9_.\-\[\] ]+$`)
+
+const (
+	// sectionDivision is the default separator of section and key like "db.host"
+	sectionDivision = "."
+	// attributeDivision is the default separator of nested keys like "db.master.host"
 	attributeDivision = "."
 	lineBreak         = "\n"
 )
 
+// CaseMode defines how names of sections and keys are compared
+type CaseMode int
+
+const (
+	// CaseInsensitive lowercases names, which is the default
+	CaseInsensitive CaseMode = iota
+	// CaseSensitive keeps names, so API_Key and api_key are different keys
+	CaseSensitive
+	// CasePreserving keeps names as first written, but compares them case insensitively
+	CasePreserving
+)
+
+// IniConfig parses ini data, the charset is detected by bom unless WithCharset is given
 type IniConfig struct {
+	charset           string
+	caseMode          CaseMode
+	sectionDivision   string
+	attributeDivision string
+	template          bool
+}
+
+// IniOption configures how IniConfig parses data
+type IniOption func(ini *IniConfig)
+
+// WithCharset decodes data by the registered charset, like "gbk" or "utf-16le"
+func WithCharset(name string) IniOption {
+	return func(ini *IniConfig) {
+		ini.charset = name
+	}
 }
 
-//Parse parse ini file
+// WithCaseMode sets how names of sections and keys are compared, CaseInsensitive by default
+func WithCaseMode(mode CaseMode) IniOption {
+	return func(ini *IniConfig) {
+		ini.caseMode = mode
+	}
+}
+
+// WithDivisions sets the separators used by Get and Set, like "db::host" and "db::master.host"
+// by WithDivisions("::", "."), the empty division keeps the default ".".
+func WithDivisions(section, attribute string) IniOption {
+	return func(ini *IniConfig) {
+		ini.sectionDivision = section
+		ini.attributeDivision = attribute
+	}
+}
+
+// WithTemplate renders all data as config template before parsing, see RenderTemplate,
+// otherwise only the files with TemplateExt loaded by NewConfig, Load and LoadDir are rendered.
+func WithTemplate() IniOption {
+	return func(ini *IniConfig) {
+		ini.template = true
+	}
+}
+
+// NewIniConfig returns an IniConfig configured by opts
+func NewIniConfig(opts ...IniOption) *IniConfig {
+	ini := new(IniConfig)
+	for _, opt := range opts {
+		opt(ini)
+	}
+	return ini
+}
+
+// Parse parse ini file
 func (ini *IniConfig) Parse(fileName string) (Provider, error) {
-	data, err := ioutil.ReadFile(fileName)
+	f, err := os.Open(fileName)
 	if err != nil {
 		return nil, err
 	}
-	return ini.ParseData(data)
+	defer f.Close()
+	p, err := ini.parseReader(f, fileName)
+	return withSource(p, err, fileName)
+}
+
+// ParseFS parse ini file in fsys
+func (ini *IniConfig) ParseFS(fsys fs.FS, name string) (Provider, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := ini.parseReader(f, name)
+	return withSource(p, err, name)
 }
 
 // ParseData parse ini bytes data
 func (ini *IniConfig) ParseData(data []byte) (Provider, error) {
-	c := &Container{
-		data:             make(map[string]map[string]string),
-		sectionComment:   make(map[string]string),
-		attributeComment: make(map[string]string),
-		RWMutex:          sync.RWMutex{},
-		list:             list.New(),
+	return ini.ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parse ini data from reader line by line
+func (ini *IniConfig) ParseReader(r io.Reader) (Provider, error) {
+	return ini.parseReader(r, "ini")
+}
+
+// parseReader parses ini data, name is the template name when ini renders data
+func (ini *IniConfig) parseReader(r io.Reader, name string) (Provider, error) {
+	r, charset, bom, err := decodeReader(r, ini.charset)
+	if err != nil {
+		return nil, err
 	}
+	if ini.template {
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = RenderTemplate(name, data); err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+	c := newContainer()
 	c.RWMutex.Lock()
 	defer c.RWMutex.Unlock()
+	c.charset, c.bom = charset, bom
+	c.caseMode = ini.caseMode
+	c.sectionDivision, c.attributeDivision = ini.sectionDivision, ini.attributeDivision
 
-	buf := bufio.NewReader(bytes.NewReader(data))
-	// check file bom
-	bom, err := buf.Peek(3)
-	if err == nil && bom[0] == 239 && bom[1] == 187 && bom[2] == 191 {
-		for i := 1; i <= 3; i++ {
-			buf.ReadByte()
-		}
-	}
-	// read by lines
+	// read by lines, the file bom is skipped
+	lines := newLineReader(r)
 	var comment bytes.Buffer
 	section := defaultSection
 	for {
-		line, _, err := buf.ReadLine()
+		line, err := lines.next()
 		if err == io.EOF {
 			break
 		}
+		if err != nil {
+			return nil, err
+		}
 		// trim space
 		line = bytes.TrimSpace(line)
 		// skip empty line
@@ -102,40 +202,24 @@ func (ini *IniConfig) ParseData(data []byte) (Provider, error) {
 		}
 		// parse section
 		if bytes.HasPrefix(line, byteSectionStart) && bytes.HasSuffix(line, byteSectionEnd) {
-			section = strings.ToLower(string(line[1 : len(line)-1]))
+			section = c.sectionName(string(line[1 : len(line)-1]))
 			if comment.Len() > 0 {
 				c.sectionComment[section] = comment.String()
 				comment.Reset()
 			}
-			// if section is not set, init it
-			if _, ok := c.data[section]; !ok {
-				// when attribute be annotated,
-				// avoid section name could't write into save file
-				c.data[section] = make(map[string]string)
-				// ensure original sort
-				listMap := make(map[string]*list.List)
-				listMap[section] = list.New()
-				c.list.PushBack(listMap)
-			}
+			// when attribute be annotated,
+			// avoid section name could't write into save file
+			c.addSection(section)
 			continue
 		}
 		// parse attribute
 		if split := bytes.Split(line, byteAssign); split != nil {
-			// if section is not set, init it
-			if _, ok := c.data[section]; !ok {
-				c.data[section] = make(map[string]string)
-				// ensure original sort
-				listMap := make(map[string]*list.List)
-				listMap[section] = list.New()
-				c.list.PushBack(listMap)
-			}
-
 			// support attribute's value appear byteAssign, which must has prefix byteQuote
 			if len(split) > 2 && !bytes.HasPrefix(bytes.TrimSpace(split[1]), byteQuote) {
 				return nil, fmt.Errorf("read content err:the \"%s\" in %s should appear only once", byteAssign, string(line))
 			}
 
-			key := strings.ToLower(string(bytes.TrimSpace(split[0])))
+			key := c.keyName(section, string(bytes.TrimSpace(split[0])))
 			keyValue := bytes.TrimSpace(bytes.Join(split[1:], byteAssign))
 			keyValue = bytes.Replace(keyValue, byteQuote, byteEmpty, -1)
 			// support comment likes below
@@ -146,9 +230,7 @@ func (ini *IniConfig) ParseData(data []byte) (Provider, error) {
 				comment.Write(valSplit[1])
 			}
 			keyValue = valSplit[0]
-			c.data[section][key] = string(keyValue)
-			// ensure original sort
-			c.list.Back().Value.(map[string]*list.List)[section].PushBack(key)
+			c.setValue(section, key, string(keyValue))
 			if comment.Len() > 0 {
 				c.attributeComment[section+attributeDivision+key] = comment.String()
 				comment.Reset()
@@ -159,175 +241,203 @@ func (ini *IniConfig) ParseData(data []byte) (Provider, error) {
 	return c, nil
 }
 
+// Marshal writes the document as ini bytes data
+func (ini *IniConfig) Marshal(doc *Document) ([]byte, error) {
+	parseComment := func(comment string) string {
+		if len(strings.TrimSpace(comment)) == 0 {
+			return string(byteWellNumber)
+		}
+		prefix := string(byteWellNumber)
+		return prefix + strings.Replace(comment, lineBreak, lineBreak+prefix, -1)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for _, section := range doc.Sections {
+		// write section comment
+		if section.Comment != "" {
+			buf.WriteString(parseComment(section.Comment) + lineBreak)
+		}
+		// write section name
+		buf.WriteString(string(byteSectionStart) + section.Name + string(byteSectionEnd) + lineBreak)
+		for _, entry := range section.Entries {
+			if entry.Key == "" {
+				continue
+			}
+			// write attribute comment
+			if entry.Comment != "" {
+				buf.WriteString(parseComment(entry.Comment) + lineBreak)
+			}
+			// value contains byteAssign must be quoted, otherwise it can't be parsed again
+			val := entry.Value
+			if strings.Contains(val, string(byteAssign)) {
+				val = string(byteQuote) + val + string(byteQuote)
+			}
+			// write key and value
+			buf.WriteString(entry.Key + string(byteAssign) + val + lineBreak)
+			buf.WriteString(lineBreak)
+		}
+		// Put a line between sections.
+		buf.WriteString(lineBreak)
+	}
+	return buf.Bytes(), nil
+}
+
 type Container struct {
 	sync.RWMutex
 	data             map[string]map[string]string
 	list             *list.List
 	sectionComment   map[string]string
 	attributeComment map[string]string
+	// parent is looked up when the key isn't set
+	parent Provider
+	sep    Separators
+	// source is where the data is loaded from, sources records the keys from elsewhere
+	source  string
+	sources map[string]string
+	// charset and bom of parsed data, which SaveFile writes back in
+	charset string
+	bom     bool
+	// how names are compared and split, see IniOption
+	caseMode          CaseMode
+	sectionDivision   string
+	attributeDivision string
+	// folded maps lower case names to stored names for CasePreserving,
+	// keys are indexed by "section.key"
+	folded map[string]string
+	watch  watchState
+	// update serializes Update, validators check the result of changes
+	update     sync.Mutex
+	validators []func(p Provider) error
 }
 
-// Set writes a new value for key.
+// Set writes a new value for key, watchers are notified when the value changes.
 // if write to one section, the key need be "section::key", otherwise write to default section.
+// The new value is checked by the validators like Update.
 func (c *Container) Set(key, value string) error {
+	return c.change(func(tx Tx) error { return tx.Set(key, value) })
+}
+
+func (c *Container) set(key, value string) error {
 	c.Lock()
 	defer c.Unlock()
 
 	if key == "" {
 		return errors.New("key is empty")
 	}
-	// ensure original sort
-	var listMap = make(map[string]*list.List)
-
 	section, k := c.parseSectionKey(key)
-	if _, ok := c.data[section]; !ok {
-		c.data[section] = make(map[string]string)
-		listMap[section] = list.New()
-		c.list.PushBack(listMap)
-	}else{
-		for e := c.list.Front(); e != nil ; e = e.Next() {
-			element := e.Value.(map[string]*list.List)
-			for sectionAlias, key := range element{
-				if sectionAlias == section {
-					listMap[section] = key
-					break
-				}
-			}
-		}
-	}
-	c.data[section][k] = value
-	listMap[section].PushBack(k)
+	c.setValue(section, k, value)
+	c.sources[section+attributeDivision+k] = sourceSet
 	return nil
 }
 
-// Get retrieves the raw value by a given key
+// Get retrieves the value by a given key, the encrypted value is decrypted,
+// the value like "@file:/run/secrets/name" is read from the file,
+// and empty string is returned when it can't be resolved.
 // if get one section key, the key need be "section::key", otherwise write to default section.
 func (c *Container) Get(key string) string {
-	c.RLock()
-	defer c.RUnlock()
+	val, _, _ := c.resolve(key)
+	return val
+}
+
+// Delete removes the key and its comment, watchers are notified when the value changes.
+// for section, the key need to be "section::key", otherwise removes from the default section
+// The result is checked by the validators like Update.
+func (c *Container) Delete(key string) error {
+	return c.change(func(tx Tx) error { return tx.Delete(key) })
+}
+
+func (c *Container) delete(key string) error {
+	c.Lock()
+	defer c.Unlock()
+
 	if key == "" {
-		return ""
+		return errors.New("key is empty")
 	}
 	section, k := c.parseSectionKey(key)
-	val, ok := c.data[section][k]
-	if !ok {
-		return ""
+	if !c.deleteValue(section, k) {
+		return fmt.Errorf("key %s not find", key)
 	}
-	return val
+	return nil
 }
 
 // Has retrieves whether the key exist.
 // for section, the key need to be "section::key", otherwise retrieves the default section
 func (c *Container) Has(key string) bool {
-	c.RLock()
-	defer c.RUnlock()
-	if key == "" {
-		return false
-	}
-	section, k := c.parseSectionKey(key)
-	if _, ok := c.data[section]; !ok {
-		return false
-	}
-	if _, ok := c.data[section][k]; !ok {
-		return false
-	}
-	return true
+	_, ok := c.lookup(key)
+	return ok
 }
 
-// SaveFile save the config into file.
+// SaveFile save the config into file,
+// which is written in the charset and bom of parsed data.
 func (c *Container) SaveFile(filename string) error {
-	f, err := os.Create(filename)
+	data, err := new(IniConfig).Marshal(c.Document())
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-
-	parseSectionComment := func(section, key string) string {
-		var (
-			comment string
-			ok      bool
-		)
-		if key == "" {
-			comment, ok = c.sectionComment[section]
-		} else {
-			comment, ok = c.attributeComment[section+attributeDivision+key]
-		}
-		if ok {
-			if len(comment) == 0 || len(strings.TrimSpace(comment)) == 0 {
-				return string(byteWellNumber)
-			}
-			prefix := string(byteWellNumber)
-			return prefix + strings.Replace(comment, lineBreak, lineBreak+prefix, -1)
-		}
-		return ""
+	c.RLock()
+	charset, bom := c.charset, c.bom
+	c.RUnlock()
+	if data, err = encodeData(data, charset, bom); err != nil {
+		return err
 	}
+	return ioutil.WriteFile(filename, data, 0644)
+}
 
-	buf := bytes.NewBuffer(nil)
-	// Save named sections
-	for {
-		element := c.list.Front()
-		if element == nil {
-			break
-		}
+// Document retrieves a snapshot of the raw config data,
+// sections and keys keep their original order.
+func (c *Container) Document() *Document {
+	c.RLock()
+	defer c.RUnlock()
 
-		sectionList := element.Value.(map[string]*list.List)
-	sectionNext:
-		for section, keyList := range sectionList {
-			// write section comment
-			if comment := parseSectionComment(section, ""); comment != "" {
-				if _, err := buf.WriteString(comment + lineBreak); err != nil {
-					return err
-				}
-			}
-			// write section name
-			if _, err := buf.WriteString(string(byteSectionStart) + section + string(byteSectionEnd) + lineBreak); err != nil {
-				return err
+	doc := new(Document)
+	for e := c.list.Front(); e != nil; e = e.Next() {
+		for section, keyList := range e.Value.(map[string]*list.List) {
+			s := Section{
+				Name:    section,
+				Comment: c.sectionComment[section],
 			}
-			for {
-				keyElement := keyList.Front()
-				if keyElement == nil {
-					break sectionNext
-				}
-				k := keyElement.Value.(string)
-				if k != "" {
-					val := c.data[section][k]
-					// write attribute comment
-					if comment := parseSectionComment(section, k); comment != "" {
-						if _, err := buf.WriteString(comment + lineBreak); err != nil {
-							return err
-						}
-					}
-					// write key and value
-					if _, err := buf.WriteString(k + string(byteAssign) + val + lineBreak); err != nil {
-						return err
-					}
-					if _, err = buf.WriteString(lineBreak); err != nil {
-						return err
-					}
-				}
-				keyList.Remove(keyElement)
+			for ke := keyList.Front(); ke != nil; ke = ke.Next() {
+				k := ke.Value.(string)
+				s.Entries = append(s.Entries, Entry{
+					Key:     k,
+					Value:   c.data[section][k],
+					Comment: c.attributeComment[section+attributeDivision+k],
+				})
 			}
+			doc.Sections = append(doc.Sections, s)
 		}
-		// Put a line between sections.
-		if _, err = buf.WriteString(lineBreak); err != nil {
-			return err
-		}
-		c.list.Remove(element)
 	}
-	_, err = buf.WriteTo(f)
-	return err
+	return doc
 }
 
 // GetSection retrieves section data
 // if section is empty, default section data will back
+// keys of parent section are merged when the parent is set.
 func (c *Container) GetSection(section string) (map[string]string, error) {
 	if section == "" {
 		section = defaultSection
 	}
-	if data, ok := c.data[section]; ok {
-		return data, nil
+	c.RLock()
+	parent := c.parent
+	c.RUnlock()
+	var merged map[string]string
+	if parent != nil {
+		merged, _ = parent.GetSection(section)
 	}
-	return nil, fmt.Errorf("section %s not find", section)
+	c.RLock()
+	defer c.RUnlock()
+	data, ok := c.data[c.sectionName(section)]
+	if !ok && merged == nil {
+		return nil, fmt.Errorf("section %s not find", section)
+	}
+	result := make(map[string]string, len(data)+len(merged))
+	for k, v := range merged {
+		result[k] = v
+	}
+	for k, v := range data {
+		result[k], _ = resolveValue(v)
+	}
+	return result, nil
 }
 
 // String retrieves key's value, which format is string
@@ -335,13 +445,93 @@ func (c *Container) String(key string) string {
 	return c.Get(key)
 }
 
-// Strings retrieves key's slice value, which format is []string
+// Strings retrieves key's slice value, which format is []string.
+// items are split by list separator and trimmed, the item quoted by ' or " can contain separator,
+// note the ini parser drops " and treats ; as inline comment, use ' and another separator in ini files.
 func (c *Container) Strings(key string) []string {
 	v := c.String(key)
 	if v == "" {
 		return nil
 	}
-	return strings.Split(v, ";")
+	return splitQuoted(v, c.separators().List)
+}
+
+// Ints return int slice value of given key
+func (c *Container) Ints(key string) ([]int, error) {
+	items := c.Strings(key)
+	values := make([]int, 0, len(items))
+	for _, item := range items {
+		v, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// Bools return bool slice value of given key
+func (c *Container) Bools(key string) ([]bool, error) {
+	items := c.Strings(key)
+	values := make([]bool, 0, len(items))
+	for _, item := range items {
+		v, err := ParseBool(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// Floats return float64 slice value of given key
+func (c *Container) Floats(key string) ([]float64, error) {
+	items := c.Strings(key)
+	values := make([]float64, 0, len(items))
+	for _, item := range items {
+		v, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// StringMap return map value of given key, which format is "k1:v1,k2:v2",
+// the pair and value separators can be changed by SetSeparators.
+func (c *Container) StringMap(key string) (map[string]string, error) {
+	v := c.String(key)
+	if v == "" {
+		return nil, nil
+	}
+	sep := c.separators()
+	return splitMap(v, sep.Pair, sep.Value)
+}
+
+// SetSeparators set separators of list and map values,
+// the empty field keeps the default separator.
+func (c *Container) SetSeparators(sep Separators) {
+	c.Lock()
+	defer c.Unlock()
+	c.sep = sep
+}
+
+// separators retrieves the separators with defaults
+func (c *Container) separators() Separators {
+	c.RLock()
+	sep := c.sep
+	c.RUnlock()
+	if sep.List == "" {
+		sep.List = ";"
+	}
+	if sep.Pair == "" {
+		sep.Pair = ","
+	}
+	if sep.Value == "" {
+		sep.Value = ":"
+	}
+	return sep
 }
 
 // Int return Int value of given key
@@ -364,6 +554,21 @@ func (c *Container) Float(key string) (float64, error) {
 	return strconv.ParseFloat(c.Get(key), 64)
 }
 
+// Duration return duration value of given key, such as "30s", number without unit is seconds
+func (c *Container) Duration(key string) (time.Duration, error) {
+	return ParseDuration(c.Get(key))
+}
+
+// Bytes return the number of bytes of given key, such as "128M", "10MB" or "1GiB"
+func (c *Container) Bytes(key string) (int64, error) {
+	return ParseBytes(c.Get(key))
+}
+
+// Time return time value of given key parsed by layouts, see ParseTime
+func (c *Container) Time(key string, layouts ...string) (time.Time, error) {
+	return ParseTime(c.Get(key), layouts...)
+}
+
 // DefaultString returns the string value for a given key.
 // if err != nil return defaultVal
 func (c *Container) DefaultString(key, defaultVal string) string {
@@ -385,7 +590,7 @@ func (c *Container) DefaultStrings(key string, defaultVal []string) []string {
 }
 
 // DefaultInt returns the integer value for a given key.
-// if err != nil return defaultVal
+// if err != nil return defaultVal, use MustInt or Checker to report invalid value like "80a"
 func (c *Container) DefaultInt(key string, defaultVal int) int {
 	value, err := c.Int(key)
 	if err != nil {
@@ -424,23 +629,329 @@ func (c *Container) DefaultFloat(key string, defaultVal float64) float64 {
 	return value
 }
 
-// parseSectionKey retrieves the key
+// DefaultDuration returns the duration value for a given key.
+// if err != nil return defaultVal
+func (c *Container) DefaultDuration(key string, defaultVal time.Duration) time.Duration {
+	value, err := c.Duration(key)
+	if err != nil {
+		value = defaultVal
+	}
+	return value
+}
+
+// DefaultBytes returns the number of bytes for a given key.
+// if err != nil return defaultVal
+func (c *Container) DefaultBytes(key string, defaultVal int64) int64 {
+	value, err := c.Bytes(key)
+	if err != nil {
+		value = defaultVal
+	}
+	return value
+}
+
+// DefaultTime returns the time value for a given key.
+// if err != nil return defaultVal
+func (c *Container) DefaultTime(key string, defaultVal time.Time, layouts ...string) time.Time {
+	value, err := c.Time(key, layouts...)
+	if err != nil {
+		value = defaultVal
+	}
+	return value
+}
+
+// Require checks all keys exist and can be resolved,
+// the error lists every missing or unresolvable key as KeyErrors
+func (c *Container) Require(keys ...string) error {
+	var errs KeyErrors
+	for _, key := range keys {
+		_, ok, err := c.resolve(key)
+		if !ok {
+			errs = append(errs, &KeyError{Key: key, Err: ErrKeyNotFound})
+		} else if err != nil {
+			errs = append(errs, &KeyError{Key: key, Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// MustString returns the string value for a given key.
+// panic with *KeyError if the key isn't set or can't be resolved
+func (c *Container) MustString(key string) string {
+	return c.String(c.mustHave(key))
+}
+
+// MustInt returns the integer value for a given key.
+// panic with *KeyError if the key isn't set, can't be resolved or parsed
+func (c *Container) MustInt(key string) int {
+	value, err := c.Int(c.mustHave(key))
+	mustParse(key, err)
+	return value
+}
+
+// MustInt64 returns the int64 value for a given key.
+// panic with *KeyError if the key isn't set, can't be resolved or parsed
+func (c *Container) MustInt64(key string) int64 {
+	value, err := c.Int64(c.mustHave(key))
+	mustParse(key, err)
+	return value
+}
+
+// MustBool returns the boolean value for a given key.
+// panic with *KeyError if the key isn't set, can't be resolved or parsed
+func (c *Container) MustBool(key string) bool {
+	value, err := c.Bool(c.mustHave(key))
+	mustParse(key, err)
+	return value
+}
+
+// MustFloat returns the float64 value for a given key.
+// panic with *KeyError if the key isn't set, can't be resolved or parsed
+func (c *Container) MustFloat(key string) float64 {
+	value, err := c.Float(c.mustHave(key))
+	mustParse(key, err)
+	return value
+}
+
+// MustDuration returns the duration value for a given key.
+// panic with *KeyError if the key isn't set, can't be resolved or parsed
+func (c *Container) MustDuration(key string) time.Duration {
+	value, err := c.Duration(c.mustHave(key))
+	mustParse(key, err)
+	return value
+}
+
+// mustHave panics with *KeyError if the key isn't set or can't be resolved
+func (c *Container) mustHave(key string) string {
+	if err := requireKey(c, key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// mustParse panics with *KeyError if err != nil
+func mustParse(key string, err error) {
+	if err != nil {
+		panic(&KeyError{Key: key, Err: err})
+	}
+}
+
+// newContainer returns an empty container
+func newContainer() *Container {
+	return &Container{
+		data:             make(map[string]map[string]string),
+		sectionComment:   make(map[string]string),
+		attributeComment: make(map[string]string),
+		sources:          make(map[string]string),
+		folded:           make(map[string]string),
+		list:             list.New(),
+	}
+}
+
+// newContainerDocument builds a container from the document
+func newContainerDocument(doc *Document) *Container {
+	c := newContainer()
+	for _, s := range doc.Sections {
+		c.addSection(s.Name)
+		if s.Comment != "" {
+			c.sectionComment[s.Name] = s.Comment
+		}
+		for _, e := range s.Entries {
+			c.setValue(s.Name, e.Key, e.Value)
+			if e.Comment != "" {
+				c.attributeComment[s.Name+attributeDivision+e.Key] = e.Comment
+			}
+		}
+	}
+	return c
+}
+
+// replace swaps the data of c with the data of src and notifies watchers,
+// parent and separators of c are kept, src must not be used after it.
+func (c *Container) replace(src *Container) {
+	c.update.Lock()
+	c.swap(src)
+	c.update.Unlock()
+	c.notify()
+}
+
+// swap moves the data of src into c, the caller must hold c.update
+func (c *Container) swap(src *Container) {
+	src.RLock()
+	defer src.RUnlock()
+	c.Lock()
+	defer c.Unlock()
+	c.data, c.list = src.data, src.list
+	c.sectionComment, c.attributeComment = src.sectionComment, src.attributeComment
+	c.source, c.sources = src.source, src.sources
+	c.charset, c.bom = src.charset, src.bom
+	c.caseMode, c.folded = src.caseMode, src.folded
+	c.sectionDivision, c.attributeDivision = src.sectionDivision, src.attributeDivision
+}
+
+// addSection init the section if it is not set,
+// the caller must hold the lock.
+func (c *Container) addSection(section string) {
+	if _, ok := c.data[section]; ok {
+		return
+	}
+	c.data[section] = make(map[string]string)
+	c.folded[strings.ToLower(section)] = section
+	// ensure original sort
+	listMap := make(map[string]*list.List)
+	listMap[section] = list.New()
+	c.list.PushBack(listMap)
+}
+
+// sectionList retrieves the key list which keeps the original sort of section
+func (c *Container) sectionList(section string) *list.List {
+	for e := c.list.Front(); e != nil; e = e.Next() {
+		if keyList, ok := e.Value.(map[string]*list.List)[section]; ok {
+			return keyList
+		}
+	}
+	return nil
+}
+
+// setValue writes value into section, a new key is appended to the end of section,
+// the caller must hold the lock.
+func (c *Container) setValue(section, key, value string) {
+	c.addSection(section)
+	if _, ok := c.data[section][key]; !ok {
+		c.sectionList(section).PushBack(key)
+		c.folded[strings.ToLower(section+attributeDivision+key)] = key
+	}
+	c.data[section][key] = value
+}
+
+// deleteValue removes key and its comment from section, false is returned when the key isn't set,
+// the caller must hold the lock.
+func (c *Container) deleteValue(section, key string) bool {
+	if _, ok := c.data[section][key]; !ok {
+		return false
+	}
+	delete(c.data[section], key)
+	delete(c.folded, strings.ToLower(section+attributeDivision+key))
+	delete(c.attributeComment, section+attributeDivision+key)
+	delete(c.sources, section+attributeDivision+key)
+	keyList := c.sectionList(section)
+	for e := keyList.Front(); e != nil; e = e.Next() {
+		if e.Value.(string) == key {
+			keyList.Remove(e)
+			break
+		}
+	}
+	return true
+}
+
+// lookup retrieves the raw value of key, falls back to parent when the key isn't set
+func (c *Container) lookup(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	c.RLock()
+	section, k := c.parseSectionKey(key)
+	val, ok := c.data[section][k]
+	parent := c.parent
+	c.RUnlock()
+	if !ok && parent != nil {
+		if pc, isContainer := parent.(*Container); isContainer {
+			return pc.lookup(key)
+		}
+		if parent.Has(key) {
+			return parent.Get(key), true
+		}
+	}
+	return val, ok
+}
+
+// resolve retrieves the value of key used by application,
+// which is decrypted or read from the referenced file
+func (c *Container) resolve(key string) (string, bool, error) {
+	raw, ok := c.lookup(key)
+	if !ok {
+		return "", false, nil
+	}
+	val, err := resolveValue(raw)
+	if err != nil {
+		return "", true, err
+	}
+	return val, true, nil
+}
+
+// parseSectionKey retrieves the stored section and key
 // for section key, the key need to be "section::key", otherwise retrieves the default section
 func (c *Container) parseSectionKey(key string) (section, k string) {
 	if key == "" {
 		return
 	}
-	keys := strings.Split(strings.ToLower(key), sectionDivision)
+	sectionDiv, attributeDiv := c.divisions()
+	keys := strings.Split(key, sectionDiv)
 	if len(keys) >= 2 {
-		section = keys[0]
-		k = strings.Join(keys[1:], attributeDivision)
+		section = c.sectionName(keys[0])
+		k = c.keyName(section, strings.Join(keys[1:], attributeDiv))
 	} else {
 		section = defaultSection
-		k = keys[0]
+		k = c.keyName(section, keys[0])
 	}
 	return
 }
 
+// divisions retrieves the separators of section and nested keys with defaults,
+// the caller must hold the lock.
+func (c *Container) divisions() (section, attribute string) {
+	section, attribute = c.sectionDivision, c.attributeDivision
+	if section == "" {
+		section = sectionDivision
+	}
+	if attribute == "" {
+		attribute = attributeDivision
+	}
+	return
+}
+
+// sectionName retrieves the stored name of section by case mode,
+// the caller must hold the lock.
+func (c *Container) sectionName(section string) string {
+	switch c.caseMode {
+	case CaseSensitive:
+		return section
+	case CasePreserving:
+		if name, ok := c.folded[strings.ToLower(section)]; ok {
+			return name
+		}
+		return section
+	}
+	return strings.ToLower(section)
+}
+
+// keyName retrieves the stored name of key in section by case mode,
+// the caller must hold the lock.
+func (c *Container) keyName(section, key string) string {
+	switch c.caseMode {
+	case CaseSensitive:
+		return key
+	case CasePreserving:
+		if name, ok := c.folded[strings.ToLower(section+attributeDivision+key)]; ok {
+			return name
+		}
+		return key
+	}
+	return strings.ToLower(key)
+}
+
+// sniffIni recognizes content starts with "[section]" or "key = value"
+func sniffIni(data []byte) bool {
+	line := firstLine(data, ";#")
+	if bytes.HasPrefix(line, byteSectionStart) {
+		return bytes.HasSuffix(line, byteSectionEnd)
+	}
+	i := bytes.Index(line, byteAssign)
+	return i > 0 && iniKey.Match(bytes.TrimSpace(line[:i]))
+}
+
 func init() {
-	Register("ini", &IniConfig{})
+	Register("ini", &IniConfig{}, WithExtensions(".ini", ".conf", ".cfg"), WithSniffer(sniffIni))
 }
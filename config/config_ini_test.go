@@ -15,11 +15,16 @@
 package config
 
 import (
+	"errors"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 var (
@@ -62,6 +67,14 @@ func TestIni(t *testing.T) {
 	if container.Get("test.aaa") != "test1" {
 		t.Fatal("set test1 error")
 	}
+	// test Delete
+	if err := container.Delete("test.aaa"); err != nil || container.Has("test.aaa") {
+		t.Fatal("delete test.aaa error")
+	}
+	if err := container.Delete("test.aaa"); err == nil {
+		t.Fatal("test.aaa shouldn't exist")
+	}
+	container.Set("test.aaa", "test1")
 	// test Has
 	if !container.Has("php.engine") {
 		t.Fatal("file has php.engine setting")
@@ -148,6 +161,123 @@ func TestHelper(t *testing.T) {
 	}
 }
 
+func TestUnitHelper(t *testing.T) {
+	// test Bytes with php.ini style size
+	if v, err := container.Bytes("php.memory_limit"); err != nil || v != 128<<20 {
+		t.Fatalf("php.memory_limit is %d %v", v, err)
+	}
+	// test Duration with php.ini style seconds
+	if v, err := container.Duration("php.max_execution_time"); err != nil || v != 120*time.Second {
+		t.Fatalf("php.max_execution_time is %v %v", v, err)
+	}
+	c, _ := NewConfigData("ini", []byte("timeout = 1m30s\nmax_body = 10MB\nstart = 2026-01-01T00:00:00Z\nday = Jan 1, 2026\nport = 80a\n"))
+	if v, _ := c.Duration("timeout"); v != 90*time.Second {
+		t.Fatalf("timeout is %v", v)
+	}
+	if v, _ := c.Bytes("max_body"); v != 10e6 {
+		t.Fatalf("max_body is %d", v)
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if v, err := c.Time("start"); err != nil || !v.Equal(start) {
+		t.Fatalf("start is %v %v", v, err)
+	}
+	if v, err := c.Time("day", "Jan 2, 2006"); err != nil || !v.Equal(start) {
+		t.Fatalf("day is %v %v", v, err)
+	}
+	if _, err := c.Time("day"); err == nil {
+		t.Fatal("day doesn't match default layouts")
+	}
+	// test Default*
+	if v := c.DefaultDuration("port", time.Second); v != time.Second {
+		t.Fatalf("DefaultDuration is %v", v)
+	}
+	if v := c.DefaultBytes("port", 1); v != 1 {
+		t.Fatalf("DefaultBytes is %d", v)
+	}
+	if v := c.DefaultTime("port", start); !v.Equal(start) {
+		t.Fatalf("DefaultTime is %v", v)
+	}
+}
+
+func TestListHelper(t *testing.T) {
+	// ";" starts an inline comment in ini files, so values are set directly
+	c := newContainer()
+	c.Set("ports", "80; 443 ;8080")
+	c.Set("flags", "on;off;1")
+	c.Set("ratios", "0.5;1")
+	c.Set("names", "a; 'b;c' ;  d")
+	c.Set("hosts", "web:10.0.0.1, db:'10.0.0.2,3', api:http://10.0.0.4")
+	c.Set("bad", "1;x")
+	c.Set("broken", "a,b")
+	if v, err := c.Ints("ports"); err != nil || !reflect.DeepEqual(v, []int{80, 443, 8080}) {
+		t.Fatalf("ports is %v %v", v, err)
+	}
+	if v, err := c.Bools("flags"); err != nil || !reflect.DeepEqual(v, []bool{true, false, true}) {
+		t.Fatalf("flags is %v %v", v, err)
+	}
+	if v, err := c.Floats("ratios"); err != nil || !reflect.DeepEqual(v, []float64{0.5, 1}) {
+		t.Fatalf("ratios is %v %v", v, err)
+	}
+	if v := c.Strings("names"); !reflect.DeepEqual(v, []string{"a", "b;c", "d"}) {
+		t.Fatalf("names is %q", v)
+	}
+	want := map[string]string{"web": "10.0.0.1", "db": "10.0.0.2,3", "api": "http://10.0.0.4"}
+	if v, err := c.StringMap("hosts"); err != nil || !reflect.DeepEqual(v, want) {
+		t.Fatalf("hosts is %v %v", v, err)
+	}
+	if _, err := c.Ints("bad"); err == nil {
+		t.Fatal("x isn't int")
+	}
+	if _, err := c.Bools("bad"); err == nil {
+		t.Fatal("x isn't bool")
+	}
+	if _, err := c.Floats("bad"); err == nil {
+		t.Fatal("x isn't float")
+	}
+	if _, err := c.StringMap("broken"); err == nil {
+		t.Fatal("broken isn't map")
+	}
+	if v, err := c.StringMap("aaa"); err != nil || v != nil {
+		t.Fatal("aaa isn't set")
+	}
+	// test SetSeparators
+	c.SetSeparators(Separators{List: ",", Value: "="})
+	c.Set("ports", "1, 2")
+	c.Set("hosts", "a=1,b=2")
+	if v, _ := c.Ints("ports"); !reflect.DeepEqual(v, []int{1, 2}) {
+		t.Fatalf("ports is %v", v)
+	}
+	if v, _ := c.StringMap("hosts"); !reflect.DeepEqual(v, map[string]string{"a": "1", "b": "2"}) {
+		t.Fatalf("hosts is %v", v)
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	cases := map[string]int64{
+		"512":      512,
+		"-1":       -1,
+		"128M":     128 << 20,
+		"1k":       1024,
+		"2G":       2 << 30,
+		"1.5GiB":   3 << 29,
+		"10 KiB":   10 << 10,
+		"10MB":     10e6,
+		"1gb":      1e9,
+		"64B":      64,
+		"8388607T": 8388607 << 40,
+	}
+	for value, want := range cases {
+		if v, err := ParseBytes(value); err != nil || v != want {
+			t.Fatalf("parse %s: got %d %v, want %d", value, v, err, want)
+		}
+	}
+	for _, value := range []string{"", "M", "10X", "1..2M", "-1M", "8388608T", "9223372036854775808B"} {
+		if _, err := ParseBytes(value); err == nil {
+			t.Fatalf("parse %s should fail", value)
+		}
+	}
+}
+
 func TestExpose(t *testing.T) {
 	// test NewXXX
 	b, _ := ioutil.ReadFile(configFile)
@@ -169,6 +299,34 @@ func TestExpose(t *testing.T) {
 		t.Fatal("parse section error")
 	}
 }
+
+func TestParseReader(t *testing.T) {
+	long := strings.Repeat("x", 100*1024)
+	cases := map[string]string{
+		"ini":  "[a]\nlong = " + long + "\nshort = 1",
+		"json": `{"a": {"long": "` + long + `", "short": 1}}`,
+		"yaml": "a:\n  long: " + long + "\n  short: 1",
+		"toml": "[a]\nlong = \"" + long + "\"\nshort = 1",
+	}
+	for adapterName, data := range cases {
+		c, err := NewConfigReader(adapterName, strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %v", adapterName, err)
+		}
+		if c.Get("a.long") != long || c.Get("a.short") != "1" {
+			t.Fatalf("%s: long line is split", adapterName)
+		}
+		// reader error must be reported
+		r := io.MultiReader(strings.NewReader(data[:10]), iotest.ErrReader(errors.New("broken pipe")))
+		if _, err := NewConfigReader(adapterName, r); err == nil {
+			t.Fatalf("%s: reader error is ignored", adapterName)
+		}
+	}
+	if _, err := NewConfigReader("aaa", strings.NewReader("")); err == nil {
+		t.Fatal("adapter aaa shouldn't exist")
+	}
+}
+
 func TestParseBool(t *testing.T) {
 	if _, err := ParseBool(nil); err == nil {
 		t.Fatal("err shouldn't be nil")
@@ -189,3 +347,62 @@ func TestParseBool(t *testing.T) {
 		t.Fatal("bool should be false")
 	}
 }
+
+func TestCaseMode(t *testing.T) {
+	data := []byte("API_Key = a\napi_key = b\n[Database]\nHost = localhost\n")
+
+	// test insensitive by default
+	p, _ := NewIniConfig().ParseData(data)
+	if v := p.Get("API_KEY"); v != "b" {
+		t.Fatalf("API_KEY is %s", v)
+	}
+	if v := p.Get("database.host"); v != "localhost" {
+		t.Fatalf("database.host is %s", v)
+	}
+
+	// test sensitive
+	p, _ = NewIniConfig(WithCaseMode(CaseSensitive)).ParseData(data)
+	if p.Get("API_Key") != "a" || p.Get("api_key") != "b" || p.Has("API_KEY") {
+		t.Fatalf("API_Key and api_key should differ, got %s %s", p.Get("API_Key"), p.Get("api_key"))
+	}
+	if p.Has("database.host") || p.Get("Database.Host") != "localhost" {
+		t.Fatal("Database.Host should be case sensitive")
+	}
+	if _, err := p.GetSection("database"); err == nil {
+		t.Fatal("section database shouldn't exist")
+	}
+
+	// test preserving
+	p, _ = NewIniConfig(WithCaseMode(CasePreserving)).ParseData(data)
+	if v := p.Get("api_KEY"); v != "b" {
+		t.Fatalf("api_KEY is %s", v)
+	}
+	p.Set("DATABASE.HOST", "127.0.0.1")
+	p.Set("database.Port", "3306")
+	if section, _ := p.GetSection("DATABASE"); section["Host"] != "127.0.0.1" || section["Port"] != "3306" {
+		t.Fatalf("section is %v", section)
+	}
+	out, _ := new(IniConfig).Marshal(p.Document())
+	if !strings.Contains(string(out), "API_Key=b") || !strings.Contains(string(out), "[Database]\nHost=127.0.0.1") ||
+		!strings.Contains(string(out), "Port=3306") {
+		t.Fatalf("marshal %q", out)
+	}
+	if err := p.Delete("API_KEY"); err != nil || p.Has("api_key") {
+		t.Fatalf("API_Key isn't deleted, %v", err)
+	}
+	if keys := Keys(p); len(keys) != 2 || keys[0] != "Database.Host" {
+		t.Fatalf("keys are %v", keys)
+	}
+
+	// test divisions
+	p, _ = NewIniConfig(WithDivisions("::", "/")).ParseData([]byte("[db]\nmaster/host = localhost\nport = 3306\n"))
+	if v := p.Get("db::master/host"); v != "localhost" {
+		t.Fatalf("db::master/host is %s", v)
+	}
+	if p.Has("db.port") || p.Get("db::port") != "3306" {
+		t.Fatal("db::port should be found by ::")
+	}
+	if keys := Keys(p); len(keys) != 2 || keys[1] != "db::port" {
+		t.Fatalf("keys are %v", keys)
+	}
+}
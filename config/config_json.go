@@ -0,0 +1,240 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// JSONConfig parses json object into sections,
+// top level scalars belong to default section, nested objects are flattened
+// into keys joined by attributeDivision, arrays are joined by ";".
+// json has no comments, so they are dropped when writing.
+type JSONConfig struct {
+}
+
+// Parse parse json file
+func (js *JSONConfig) Parse(fileName string) (Provider, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := js.ParseReader(f)
+	return withSource(p, err, fileName)
+}
+
+// ParseFS parse json file in fsys
+func (js *JSONConfig) ParseFS(fsys fs.FS, name string) (Provider, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := js.ParseReader(f)
+	return withSource(p, err, name)
+}
+
+// ParseData parse json bytes data
+func (js *JSONConfig) ParseData(data []byte) (Provider, error) {
+	return js.ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parse json data from reader line by line
+func (js *JSONConfig) ParseReader(r io.Reader) (Provider, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	b := new(documentBuilder)
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return nil, err
+		}
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if token == json.Delim('{') {
+			section := strings.ToLower(key)
+			b.section(section)
+			if err := readObject(dec, b, section, ""); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		value, err := readValue(dec, token)
+		if err != nil {
+			return nil, err
+		}
+		b.set(defaultSection, strings.ToLower(key), value, "")
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return newContainerDocument(&b.doc), nil
+}
+
+// Marshal writes the document as json bytes data,
+// numbers and bools are written bare, other values are written as strings.
+func (js *JSONConfig) Marshal(doc *Document) ([]byte, error) {
+	if err := checkSectionConflict("json", doc); err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(nil)
+	buf.WriteString("{")
+	first := true
+	writeEntry := func(indent string, entry Entry) {
+		if !first {
+			buf.WriteString(",")
+		}
+		first = false
+		buf.WriteString(lineBreak + indent + quoteString(entry.Key) + ": " + literalValue(entry.Value))
+	}
+	// default section is written as top level keys
+	for _, section := range doc.Sections {
+		if section.Name != defaultSection {
+			continue
+		}
+		for _, entry := range section.Entries {
+			writeEntry("  ", entry)
+		}
+	}
+	for _, section := range doc.Sections {
+		if section.Name == defaultSection {
+			continue
+		}
+		if !first {
+			buf.WriteString(",")
+		}
+		buf.WriteString(lineBreak + "  " + quoteString(section.Name) + ": {")
+		first = true
+		for _, entry := range section.Entries {
+			writeEntry("    ", entry)
+		}
+		if !first {
+			buf.WriteString(lineBreak + "  ")
+		}
+		buf.WriteString("}")
+		first = false
+	}
+	buf.WriteString(lineBreak + "}" + lineBreak)
+	return buf.Bytes(), nil
+}
+
+// readObject reads members of an object whose start delim has been read
+func readObject(dec *json.Decoder, b *documentBuilder, section, prefix string) error {
+	for dec.More() {
+		key, err := readKey(dec)
+		if err != nil {
+			return err
+		}
+		key = prefix + strings.ToLower(key)
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if token == json.Delim('{') {
+			if err := readObject(dec, b, section, key+attributeDivision); err != nil {
+				return err
+			}
+			continue
+		}
+		value, err := readValue(dec, token)
+		if err != nil {
+			return err
+		}
+		b.set(section, key, value, "")
+	}
+	return expectDelim(dec, '}')
+}
+
+// readValue converts the scalar or array token to string value
+func readValue(dec *json.Decoder, token json.Token) (string, error) {
+	if token == json.Delim('[') {
+		var items []string
+		for dec.More() {
+			item, err := dec.Token()
+			if err != nil {
+				return "", err
+			}
+			if _, ok := item.(json.Delim); ok {
+				return "", fmt.Errorf("json parse: nested %v in array is not supported", item)
+			}
+			value, err := readValue(dec, item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, value)
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return "", err
+		}
+		return strings.Join(items, ";"), nil
+	}
+	switch v := token.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprintf("%t", v), nil
+	case nil:
+		return "", nil
+	}
+	return "", fmt.Errorf("json parse: unexpected %v", token)
+}
+
+// readKey reads an object key
+func readKey(dec *json.Decoder) (string, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := token.(string)
+	if !ok {
+		return "", fmt.Errorf("json parse: unexpected %v, want object key", token)
+	}
+	return key, nil
+}
+
+// expectDelim reads the delim
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("json parse: unexpected %v, want %v", token, delim)
+	}
+	return nil
+}
+
+// sniffJSON recognizes content starts with "{"
+func sniffJSON(data []byte) bool {
+	return len(data) > 0 && data[0] == '{'
+}
+
+func init() {
+	Register("json", &JSONConfig{}, WithExtensions(".json"), WithSniffer(sniffJSON))
+}
@@ -0,0 +1,237 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// KVPair is a key and its value in KVBackend
+type KVPair struct {
+	Key   string
+	Value string
+}
+
+// KVEvent is a change of key in KVBackend
+type KVEvent struct {
+	Key     string
+	Value   string
+	Deleted bool
+}
+
+// KVBackend is a key value store like etcd or consul,
+// keys are paths like "/app/db/host". Implement it by the client of the store.
+type KVBackend interface {
+	// List retrieves the pairs whose key has prefix
+	List(ctx context.Context, prefix string) ([]KVPair, error)
+	// Get retrieves the value of key, ok is false when the key doesn't exist
+	Get(ctx context.Context, key string) (value string, ok bool, err error)
+	// Watch sends the changes of keys which have prefix until ctx is done,
+	// the channel is closed then.
+	Watch(ctx context.Context, prefix string) (<-chan KVEvent, error)
+}
+
+// KVSource loads config from the keys under prefix of KVBackend, and keeps it updated by Watch.
+// The path after prefix is mapped onto config key, the first part is section,
+// so "/app/db/host" under "/app" is db.host, "/app/db/master/host" is db.master.host,
+// and "/app/name" belongs to default section.
+type KVSource struct {
+	backend  KVBackend
+	prefix   string
+	provider *Container
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewKVSource loads the keys under prefix of backend and watches their changes until Close
+func NewKVSource(ctx context.Context, backend KVBackend, prefix string) (*KVSource, error) {
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	// watch before listing, so no change is missed
+	events, err := backend.Watch(ctx, prefix)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	pairs, err := backend.List(ctx, prefix)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s := &KVSource{
+		backend:  backend,
+		prefix:   prefix,
+		provider: newContainer(),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	for _, pair := range pairs {
+		s.apply(KVEvent{Key: pair.Key, Value: pair.Value})
+	}
+	go s.watch(events)
+	return s, nil
+}
+
+// Provider retrieves the config, which is updated in place when keys change
+func (s *KVSource) Provider() Provider {
+	return s.provider
+}
+
+// Close stops watching
+func (s *KVSource) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+func (s *KVSource) watch(events <-chan KVEvent) {
+	defer close(s.done)
+	for event := range events {
+		s.apply(event)
+	}
+}
+
+// apply writes the change into provider, the keys out of prefix are ignored.
+// The backend is the source of config, so its changes aren't checked by validators like the reloads of Remote.
+func (s *KVSource) apply(event KVEvent) {
+	key := s.configKey(event.Key)
+	if key == "" {
+		return
+	}
+	c := s.provider
+	c.update.Lock()
+	c.Lock()
+	section, k := c.parseSectionKey(key)
+	if event.Deleted {
+		c.deleteValue(section, k)
+	} else {
+		c.setValue(section, k, event.Value)
+		c.sources[section+attributeDivision+k] = event.Key
+	}
+	c.Unlock()
+	c.update.Unlock()
+	c.notify()
+}
+
+// configKey maps the path onto config key
+func (s *KVSource) configKey(path string) string {
+	if !strings.HasPrefix(path, s.prefix) {
+		return ""
+	}
+	var parts []string
+	for _, part := range strings.Split(strings.TrimPrefix(path, s.prefix), "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, attributeDivision)
+}
+
+// MemoryKV is a KVBackend in memory, which is used in tests
+type MemoryKV struct {
+	sync.Mutex
+	data     map[string]string
+	watchers map[*memoryWatcher]bool
+}
+
+type memoryWatcher struct {
+	ctx    context.Context
+	prefix string
+	events chan KVEvent
+}
+
+// NewMemoryKV returns an empty MemoryKV
+func NewMemoryKV() *MemoryKV {
+	return &MemoryKV{
+		data:     make(map[string]string),
+		watchers: make(map[*memoryWatcher]bool),
+	}
+}
+
+// Put writes the value of key and notifies watchers
+func (m *MemoryKV) Put(key, value string) {
+	m.Lock()
+	defer m.Unlock()
+	m.data[key] = value
+	m.notify(KVEvent{Key: key, Value: value})
+}
+
+// Delete removes key and notifies watchers
+func (m *MemoryKV) Delete(key string) {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.data[key]; !ok {
+		return
+	}
+	delete(m.data, key)
+	m.notify(KVEvent{Key: key, Deleted: true})
+}
+
+// List implements KVBackend, the pairs are in key order
+func (m *MemoryKV) List(ctx context.Context, prefix string) ([]KVPair, error) {
+	m.Lock()
+	defer m.Unlock()
+	var pairs []KVPair
+	for key, value := range m.data {
+		if strings.HasPrefix(key, prefix) {
+			pairs = append(pairs, KVPair{Key: key, Value: value})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	return pairs, nil
+}
+
+// Get implements KVBackend
+func (m *MemoryKV) Get(ctx context.Context, key string) (string, bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	value, ok := m.data[key]
+	return value, ok, nil
+}
+
+// Watch implements KVBackend
+func (m *MemoryKV) Watch(ctx context.Context, prefix string) (<-chan KVEvent, error) {
+	w := &memoryWatcher{ctx: ctx, prefix: prefix, events: make(chan KVEvent, 16)}
+	m.Lock()
+	m.watchers[w] = true
+	m.Unlock()
+	go func() {
+		<-ctx.Done()
+		m.Lock()
+		delete(m.watchers, w)
+		close(w.events)
+		m.Unlock()
+	}()
+	return w.events, nil
+}
+
+// notify sends the event to watchers, the caller must hold the lock
+func (m *MemoryKV) notify(event KVEvent) {
+	for w := range m.watchers {
+		if !strings.HasPrefix(event.Key, w.prefix) {
+			continue
+		}
+		select {
+		case w.events <- event:
+		case <-w.ctx.Done():
+		}
+	}
+}
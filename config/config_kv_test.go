@@ -0,0 +1,77 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestKVSource(t *testing.T) {
+	kv := NewMemoryKV()
+	kv.Put("/app/name", "app")
+	kv.Put("/app/db/host", "localhost")
+	kv.Put("/app/db/master/port", "3306")
+	kv.Put("/other/name", "other")
+
+	s, err := NewKVSource(context.Background(), kv, "/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	p := s.Provider()
+	if p.Get("name") != "app" || p.Get("db.host") != "localhost" || p.Get("db.master.port") != "3306" {
+		t.Fatalf("config is %v", p.Document())
+	}
+	if p.Has("other.name") {
+		t.Fatal("keys out of prefix shouldn't be loaded")
+	}
+	if settings := Settings(p); settings[0].Source != "/app/db/host" {
+		t.Fatalf("settings are %v", settings)
+	}
+
+	// test watch
+	kv.Put("/app/db/host", "10.0.0.1")
+	kv.Delete("/app/name")
+	kv.Put("/other/debug", "on")
+	waitFor(t, func() bool { return p.Get("db.host") == "10.0.0.1" && !p.Has("name") })
+	if p.Has("other.debug") {
+		t.Fatal("keys out of prefix shouldn't be watched")
+	}
+
+	// test close stops watching
+	s.Close()
+	kv.Put("/app/db/host", "10.0.0.2")
+	time.Sleep(10 * time.Millisecond)
+	if p.Get("db.host") != "10.0.0.1" {
+		t.Fatal("closed source shouldn't be updated")
+	}
+	if value, ok, _ := kv.Get(context.Background(), "/app/db/host"); !ok || value != "10.0.0.2" {
+		t.Fatalf("/app/db/host is %s", value)
+	}
+}
+
+// waitFor waits until ok returns true
+func waitFor(t *testing.T, ok func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !ok() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
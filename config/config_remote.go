@@ -0,0 +1,249 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultPollInterval is the interval of polling remote config
+	defaultPollInterval = 30 * time.Second
+	// defaultRemoteTimeout limits a request of remote config, including reading the body
+	defaultRemoteTimeout = 10 * time.Second
+	// maxRemoteSize limits the size of remote document
+	maxRemoteSize = 10 << 20
+)
+
+// Remote fetches config from a config server over http,
+// and keeps it updated by polling with If-None-Match.
+// The provider serves the last good document when the server is unreachable or returns bad data.
+type Remote struct {
+	url       string
+	adapter   string
+	client    *http.Client
+	header    http.Header
+	cacheFile string
+	interval  time.Duration
+	timeout   time.Duration
+
+	provider *Container
+
+	mu      sync.Mutex
+	etag    string
+	lastErr error
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// RemoteOption configures Remote
+type RemoteOption func(r *Remote)
+
+// WithHTTPClient sets the client used to fetch config, http.DefaultClient by default
+func WithHTTPClient(client *http.Client) RemoteOption {
+	return func(r *Remote) {
+		r.client = client
+	}
+}
+
+// WithTimeout limits the time of a request including reading the body, 10s by default,
+// so a hanging server doesn't block NewRemote and polling.
+func WithTimeout(d time.Duration) RemoteOption {
+	return func(r *Remote) {
+		r.timeout = d
+	}
+}
+
+// WithHeader adds a request header, such as the token of config server
+func WithHeader(key, value string) RemoteOption {
+	return func(r *Remote) {
+		r.header.Add(key, value)
+	}
+}
+
+// WithCacheFile keeps a local copy of the last good document,
+// which is loaded when the server is unreachable at start.
+func WithCacheFile(name string) RemoteOption {
+	return func(r *Remote) {
+		r.cacheFile = name
+	}
+}
+
+// WithPollInterval sets the interval of polling, 30s by default
+func WithPollInterval(d time.Duration) RemoteOption {
+	return func(r *Remote) {
+		r.interval = d
+	}
+}
+
+// NewRemote fetches config from url and parses it by adapterName,
+// the local cache file is loaded when the server is unreachable.
+// Call Start to poll the changes.
+func NewRemote(url, adapterName string, opts ...RemoteOption) (*Remote, error) {
+	if _, ok := adapters[adapterName]; !ok {
+		return nil, fmt.Errorf("new remote: unknown adapter %s, register it first please", adapterName)
+	}
+	r := &Remote{
+		url:      url,
+		adapter:  adapterName,
+		client:   http.DefaultClient,
+		header:   make(http.Header),
+		interval: defaultPollInterval,
+		timeout:  defaultRemoteTimeout,
+		provider: newContainer(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if _, err := r.Refresh(); err != nil {
+		if r.cacheFile == "" {
+			return nil, err
+		}
+		data, cacheErr := ioutil.ReadFile(r.cacheFile)
+		if cacheErr != nil {
+			return nil, fmt.Errorf("%v, and cache: %v", err, cacheErr)
+		}
+		if cacheErr = r.apply(data, r.cacheFile); cacheErr != nil {
+			return nil, fmt.Errorf("%v, and cache: %v", err, cacheErr)
+		}
+	}
+	return r, nil
+}
+
+// Provider retrieves the config, which is updated in place when the remote document changes
+func (r *Remote) Provider() Provider {
+	return r.provider
+}
+
+// Refresh fetches the document when it is modified, changed reports whether it is applied.
+// The last good document is kept on error.
+func (r *Remote) Refresh() (changed bool, err error) {
+	defer func() {
+		r.mu.Lock()
+		r.lastErr = err
+		r.mu.Unlock()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return false, err
+	}
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+	r.mu.Lock()
+	if r.etag != "" {
+		req.Header.Set("If-None-Match", r.etag)
+	}
+	r.mu.Unlock()
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("remote config %s: %s", r.url, resp.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRemoteSize+1))
+	if err != nil {
+		return false, err
+	}
+	if len(data) > maxRemoteSize {
+		return false, fmt.Errorf("remote config %s: document is larger than %d bytes", r.url, maxRemoteSize)
+	}
+	if err := r.apply(data, r.url); err != nil {
+		return false, fmt.Errorf("remote config %s: %v", r.url, err)
+	}
+	r.mu.Lock()
+	r.etag = resp.Header.Get("ETag")
+	r.mu.Unlock()
+	if r.cacheFile != "" {
+		if err := writeFileAtomic(r.cacheFile, data); err != nil {
+			return true, fmt.Errorf("remote config cache %s: %v", r.cacheFile, err)
+		}
+	}
+	return true, nil
+}
+
+// Err retrieves the error of last refresh
+func (r *Remote) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lastErr
+}
+
+// Start polls the remote document in background until Close
+func (r *Remote) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop != nil {
+		return
+	}
+	r.stop, r.done = make(chan struct{}), make(chan struct{})
+	go r.poll(r.stop, r.done)
+}
+
+// Close stops polling
+func (r *Remote) Close() error {
+	r.mu.Lock()
+	stop, done := r.stop, r.done
+	r.stop, r.done = nil, nil
+	r.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	return nil
+}
+
+func (r *Remote) poll(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			r.Refresh()
+		}
+	}
+}
+
+// apply parses data and replaces the data of provider
+func (r *Remote) apply(data []byte, source string) error {
+	p, err := NewConfigData(r.adapter, data)
+	if err != nil {
+		return err
+	}
+	c, ok := p.(*Container)
+	if !ok {
+		c = newContainerDocument(p.Document())
+	}
+	c.source = source
+	r.provider.replace(c)
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// configServer serves a json document with etag
+type configServer struct {
+	sync.Mutex
+	version  int
+	data     string
+	requests int
+	down     bool
+}
+
+func (s *configServer) set(data string) {
+	s.Lock()
+	defer s.Unlock()
+	s.version++
+	s.data = data
+}
+
+func (s *configServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	s.requests++
+	if s.down {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Header.Get("Authorization") != "token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	etag := fmt.Sprintf(`"v%d"`, s.version)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	w.Write([]byte(s.data))
+}
+
+func TestRemote(t *testing.T) {
+	s := new(configServer)
+	s.set(`{"name": "app", "db": {"host": "localhost"}}`)
+	server := httptest.NewServer(s)
+	defer server.Close()
+	cacheFile := filepath.Join(t.TempDir(), "remote.json")
+
+	r, err := NewRemote(server.URL, "json", WithHeader("Authorization", "token"), WithCacheFile(cacheFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := r.Provider()
+	if p.Get("db.host") != "localhost" {
+		t.Fatalf("db.host is %s", p.Get("db.host"))
+	}
+	if s := Settings(p); s[0].Source != server.URL {
+		t.Fatalf("source is %v", s)
+	}
+
+	// test not modified
+	if changed, err := r.Refresh(); changed || err != nil {
+		t.Fatalf("refresh is %v %v", changed, err)
+	}
+	// test modified
+	s.set(`{"name": "app", "db": {"host": "10.0.0.1"}}`)
+	if changed, err := r.Refresh(); !changed || err != nil || p.Get("db.host") != "10.0.0.1" {
+		t.Fatalf("refresh is %v %v, db.host is %s", changed, err, p.Get("db.host"))
+	}
+	// test bad document keeps the last good one
+	s.set(`{"name": `)
+	if changed, err := r.Refresh(); changed || err == nil || r.Err() == nil || p.Get("db.host") != "10.0.0.1" {
+		t.Fatalf("refresh is %v %v, db.host is %s", changed, err, p.Get("db.host"))
+	}
+	// test server down keeps the last good one
+	s.Lock()
+	s.down = true
+	s.Unlock()
+	if _, err := r.Refresh(); err == nil || p.Get("db.host") != "10.0.0.1" {
+		t.Fatalf("refresh error is %v, db.host is %s", err, p.Get("db.host"))
+	}
+
+	// test cache file is loaded when server is down at start
+	r2, err := NewRemote(server.URL, "json", WithCacheFile(cacheFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := r2.Provider().Get("db.host"); v != "10.0.0.1" {
+		t.Fatalf("cached db.host is %s", v)
+	}
+	if _, err := NewRemote(server.URL, "json"); err == nil {
+		t.Fatal("server is down without cache")
+	}
+	if _, err := NewRemote(server.URL, "aaa"); err == nil {
+		t.Fatal("aaa isn't adapter")
+	}
+
+	// test polling
+	s.Lock()
+	s.down = false
+	s.Unlock()
+	s.set(`{"name": "app", "db": {"host": "10.0.0.2"}}`)
+	r3, err := NewRemote(server.URL, "json", WithHeader("Authorization", "token"), WithPollInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r3.Start()
+	defer r3.Close()
+	s.set(`{"name": "app", "db": {"host": "10.0.0.3"}}`)
+	deadline := time.Now().Add(2 * time.Second)
+	for r3.Provider().Get("db.host") != "10.0.0.3" {
+		if time.Now().After(deadline) {
+			t.Fatal("change isn't polled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	r3.Close()
+}
+
+func TestRemoteTimeout(t *testing.T) {
+	// the server hangs until the test ends
+	hang := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-hang:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(hang)
+
+	// test the cache file is loaded after timeout
+	cacheFile := filepath.Join(t.TempDir(), "remote.json")
+	if err := ioutil.WriteFile(cacheFile, []byte(`{"name": "cached"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	r, err := NewRemote(server.URL, "json", WithTimeout(50*time.Millisecond), WithCacheFile(cacheFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Fatalf("NewRemote took %v", time.Since(start))
+	}
+	if v := r.Provider().Get("name"); v != "cached" || r.Err() == nil {
+		t.Fatalf("name is %s, error is %v", v, r.Err())
+	}
+
+	// test the large document is rejected
+	large := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "`))
+		w.Write(bytes.Repeat([]byte("a"), maxRemoteSize))
+		w.Write([]byte(`"}`))
+	}))
+	defer large.Close()
+	if _, err := NewRemote(large.URL, "json"); err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Fatalf("error is %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SecretKeyEnv is the environment variable holding the base64 encoded secret key
+	SecretKeyEnv = "READYGO_CONFIG_KEY"
+	// SecretKeyFileEnv is the environment variable holding the path of secret key file,
+	// the file content is the base64 encoded secret key.
+	SecretKeyFileEnv = "READYGO_CONFIG_KEY_FILE"
+
+	secretKeySize = 32
+	encPrefix     = "ENC[AES256-GCM,"
+	encSuffix     = "]"
+	fileRefPrefix = "@file:"
+)
+
+var (
+	secretMu  sync.Mutex
+	secretKey []byte
+
+	// secretDir is the directory which file referenced secrets must be in
+	secretDir   = "/run/secrets"
+	secretFiles = make(map[string]secretFile)
+)
+
+// secretFile caches the content of file referenced secret
+type secretFile struct {
+	modTime time.Time
+	size    int64
+	value   string
+}
+
+// SetSecretKey sets the 32 bytes key to encrypt and decrypt values,
+// otherwise the key is loaded from SecretKeyEnv or SecretKeyFileEnv.
+func SetSecretKey(key []byte) error {
+	if len(key) != secretKeySize {
+		return fmt.Errorf("secret key should be %d bytes, got %d", secretKeySize, len(key))
+	}
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secretKey = append([]byte(nil), key...)
+	return nil
+}
+
+// GenerateSecretKey returns a random key encoded by base64,
+// which can be stored in SecretKeyEnv or the key file.
+func GenerateSecretKey() (string, error) {
+	key := make([]byte, secretKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// IsEncrypted reports whether the value looks like ENC[AES256-GCM,...]
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encPrefix) && strings.HasSuffix(value, encSuffix)
+}
+
+// Encrypt encrypts plain by AES-256-GCM, the result looks like ENC[AES256-GCM,...]
+// and can be written into config files.
+func Encrypt(plain string) (string, error) {
+	gcm, err := secretCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plain), nil)
+	// raw encoding has no "=", which is the assign sign of ini
+	return encPrefix + base64.RawStdEncoding.EncodeToString(sealed) + encSuffix, nil
+}
+
+// Decrypt decrypts the value encrypted by Encrypt
+func Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return "", errors.New("decrypt: value isn't encrypted")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, encPrefix), encSuffix))
+	if err != nil {
+		return "", fmt.Errorf("decrypt: %v", err)
+	}
+	gcm, err := secretCipher()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("decrypt: value is too short")
+	}
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt: %v", err)
+	}
+	return string(plain), nil
+}
+
+// secretCipher returns the AES-GCM cipher of secret key
+func secretCipher() (cipher.AEAD, error) {
+	key, err := loadSecretKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// loadSecretKey retrieves the key set by SetSecretKey,
+// or loads it from SecretKeyEnv and SecretKeyFileEnv.
+func loadSecretKey() ([]byte, error) {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	if secretKey != nil {
+		return secretKey, nil
+	}
+	encoded := os.Getenv(SecretKeyEnv)
+	if encoded == "" {
+		file := os.Getenv(SecretKeyFileEnv)
+		if file == "" {
+			return nil, fmt.Errorf("secret key isn't set, set %s or %s please", SecretKeyEnv, SecretKeyFileEnv)
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(data)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("secret key: %v", err)
+	}
+	if len(key) != secretKeySize {
+		return nil, fmt.Errorf("secret key should be %d bytes, got %d", secretKeySize, len(key))
+	}
+	secretKey = key
+	return secretKey, nil
+}
+
+// SetSecretDir sets the directory which file referenced secrets like "@file:/run/secrets/db_password"
+// must be in, relative paths are relative to it. It is "/run/secrets" by default,
+// empty dir allows any file.
+func SetSecretDir(dir string) {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secretDir = dir
+	secretFiles = make(map[string]secretFile)
+}
+
+// ReadSecretFile reads the file referenced secret, the content is trimmed,
+// and cached until the file is modified.
+func ReadSecretFile(name string) (string, error) {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	file, err := secretPath(name)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return "", err
+	}
+	if cached, ok := secretFiles[file]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.value, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSpace(string(data))
+	secretFiles[file] = secretFile{modTime: info.ModTime(), size: info.Size(), value: value}
+	return value, nil
+}
+
+// secretPath retrieves the real path of name, which must be in secretDir
+func secretPath(name string) (string, error) {
+	if secretDir == "" {
+		return filepath.Abs(name)
+	}
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(secretDir, name)
+	}
+	dir, err := filepath.EvalSymlinks(secretDir)
+	if err != nil {
+		return "", err
+	}
+	// symlinks are followed, kubernetes mounts secrets by links in the same directory
+	file, err := filepath.EvalSymlinks(name)
+	if err != nil {
+		return "", err
+	}
+	if rel, err := filepath.Rel(dir, file); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("secret file %s isn't in %s", name, secretDir)
+	}
+	return file, nil
+}
+
+// resolveValue converts the raw value to the value used by application,
+// the encrypted value is decrypted, and the file referenced value is read from the file.
+func resolveValue(raw string) (string, error) {
+	if IsEncrypted(raw) {
+		return Decrypt(raw)
+	}
+	if strings.HasPrefix(raw, fileRefPrefix) {
+		return ReadSecretFile(strings.TrimSpace(strings.TrimPrefix(raw, fileRefPrefix)))
+	}
+	return raw, nil
+}
@@ -0,0 +1,181 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecret(t *testing.T) {
+	defer func() { secretKey = nil }()
+
+	// test key from env and key file
+	key, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey = nil
+	t.Setenv(SecretKeyEnv, "")
+	t.Setenv(SecretKeyFileEnv, "")
+	if _, err := Encrypt("root"); err == nil {
+		t.Fatal("secret key isn't set")
+	}
+	keyFile := filepath.Join(t.TempDir(), "key")
+	ioutil.WriteFile(keyFile, []byte(key+"\n"), 0600)
+	t.Setenv(SecretKeyFileEnv, keyFile)
+	if _, err := Encrypt("root"); err != nil {
+		t.Fatal(err)
+	}
+	secretKey = nil
+	t.Setenv(SecretKeyEnv, "aaa")
+	if _, err := Encrypt("root"); err == nil {
+		t.Fatal("aaa isn't a valid key")
+	}
+	secretKey = nil
+	t.Setenv(SecretKeyEnv, key)
+	encrypted, err := Encrypt("p@ss;word")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(encrypted) || strings.Contains(encrypted, "=") {
+		t.Fatalf("encrypted value is %s", encrypted)
+	}
+
+	// test Get decrypts values
+	data := "[db]\nuser = root\npassword = " + encrypted + "\nbroken = ENC[AES256-GCM,aaaa]\n"
+	p, err := NewConfigData("ini", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := p.String("db.password"); v != "p@ss;word" {
+		t.Fatalf("db.password is %s", v)
+	}
+	if section, _ := p.GetSection("db"); section["password"] != "p@ss;word" {
+		t.Fatalf("db section is %v", section)
+	}
+	if v := p.Get("db.broken"); v != "" {
+		t.Fatalf("db.broken is %s", v)
+	}
+	errs, ok := p.Require("db.user", "db.password", "db.broken").(KeyErrors)
+	if !ok || len(errs) != 1 || errs[0].Key != "db.broken" {
+		t.Fatalf("db.broken can't be decrypted, got %v", errs)
+	}
+
+	// test raw value is saved
+	out, err := new(IniConfig).Marshal(p.Document())
+	if err != nil || !bytes.Contains(out, []byte(encrypted)) {
+		t.Fatalf("marshal %s %v", out, err)
+	}
+
+	// test module falls back to encrypted global value
+	app := NewApp(p)
+	app.SetModule("admin", newContainer())
+	if v := app.Module("admin").Get("db.password"); v != "p@ss;word" {
+		t.Fatalf("admin db.password is %s", v)
+	}
+
+	// test another key can't decrypt
+	other, _ := GenerateSecretKey()
+	secretKey = nil
+	t.Setenv(SecretKeyEnv, other)
+	if _, err := Decrypt(encrypted); err == nil {
+		t.Fatal("value shouldn't be decrypted by another key")
+	}
+	if _, err := Get[string](p, "db.password"); err == nil {
+		t.Fatal("Get shouldn't accept the value decrypted by another key")
+	}
+	ch := Check(p)
+	ch.String("db.password")
+	if ch.Err() == nil {
+		t.Fatal("Checker shouldn't accept the value decrypted by another key")
+	}
+	if err := SetSecretKey([]byte("short")); err == nil {
+		t.Fatal("key should be 32 bytes")
+	}
+}
+
+func TestSecretFile(t *testing.T) {
+	defer SetSecretDir("/run/secrets")
+
+	dir := t.TempDir()
+	SetSecretDir(dir)
+	ioutil.WriteFile(filepath.Join(dir, "db_password"), []byte("p@ss\n"), 0600)
+	outside := filepath.Join(t.TempDir(), "token")
+	ioutil.WriteFile(outside, []byte("token"), 0600)
+	os.Symlink(outside, filepath.Join(dir, "token"))
+
+	data := "[db]\npassword = @file:" + filepath.Join(dir, "db_password") + "\nrelative = @file:db_password\n" +
+		"outside = @file:" + outside + "\nlink = @file:token\nescape = @file:../token\nmissing = @file:aaa\n"
+	p, err := NewConfigData("ini", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := p.Get("db.password"); v != "p@ss" {
+		t.Fatalf("db.password is %q", v)
+	}
+	if v := p.Get("db.relative"); v != "p@ss" {
+		t.Fatalf("db.relative is %q", v)
+	}
+	errs, ok := p.Require("db.password", "db.outside", "db.link", "db.escape", "db.missing").(KeyErrors)
+	if !ok || len(errs) != 4 {
+		t.Fatalf("files out of secret dir shouldn't be read, got %v", errs)
+	}
+
+	// test Must, Checker and Get fail on the unresolvable values
+	for _, key := range []string{"db.escape", "db.missing"} {
+		func() {
+			defer func() {
+				if err, ok := recover().(*KeyError); !ok || err.Key != key || errors.Is(err, ErrKeyNotFound) {
+					t.Fatalf("MustString(%s) panics with %v", key, err)
+				}
+			}()
+			p.MustString(key)
+		}()
+		ch := Check(p)
+		ch.String(key)
+		if err := ch.Err(); err == nil || !strings.Contains(err.Error(), key) {
+			t.Fatalf("Checker of %s: %v", key, err)
+		}
+		if _, err := Get[string](p, key); err == nil {
+			t.Fatalf("Get of %s should fail", key)
+		}
+	}
+	if v := p.MustString("db.relative"); v != "p@ss" {
+		t.Fatalf("db.relative is %q", v)
+	}
+
+	// test cache is refreshed when file is modified
+	ioutil.WriteFile(filepath.Join(dir, "db_password"), []byte("new password"), 0600)
+	if v := p.Get("db.password"); v != "new password" {
+		t.Fatalf("db.password is %q", v)
+	}
+
+	// test the reference is saved
+	out, err := new(IniConfig).Marshal(p.Document())
+	if err != nil || !bytes.Contains(out, []byte("relative=@file:db_password")) || bytes.Contains(out, []byte("new password")) {
+		t.Fatalf("marshal %s %v", out, err)
+	}
+
+	SetSecretDir("")
+	if v := p.Get("db.outside"); v != "token" {
+		t.Fatalf("db.outside is %q", v)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const (
+	// TemplateExt is the extension of config templates, like app.ini.tmpl,
+	// which are rendered by text/template before parsed by the adapter of app.ini.
+	TemplateExt = ".tmpl"
+	// TemplateEnvVar is the environment variable holding .Env of templates, like "prod"
+	TemplateEnvVar = "READYGO_ENV"
+)
+
+var (
+	templateMu  sync.Mutex
+	templateEnv *string
+)
+
+// TemplateData is the data of config templates
+type TemplateData struct {
+	Env string // set by SetTemplateEnv, or TemplateEnvVar
+}
+
+// templateFuncs are the functions of config templates besides the builtins of text/template,
+// they only read the environment, files and commands aren't reachable.
+var templateFuncs = template.FuncMap{
+	// env retrieves the environment variable, empty when it isn't set
+	"env": os.Getenv,
+	// hostname retrieves the host name
+	"hostname": os.Hostname,
+	// default retrieves def when value is empty, like {{ env "PORT" | default "8080" }}
+	"default": func(def, value string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	},
+}
+
+// SetTemplateEnv sets .Env of templates, otherwise it is read from TemplateEnvVar
+func SetTemplateEnv(env string) {
+	templateMu.Lock()
+	defer templateMu.Unlock()
+	templateEnv = &env
+}
+
+// IsTemplate reports whether the file is a config template by its extension
+func IsTemplate(fileName string) bool {
+	return strings.EqualFold(filepath.Ext(fileName), TemplateExt)
+}
+
+// RenderTemplate renders the config template data named name,
+// such as {{ env "HOME" }}, {{ hostname }} and {{ if eq .Env "prod" }}...{{ end }}.
+func RenderTemplate(name string, data []byte) ([]byte, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+	templateMu.Lock()
+	td := TemplateData{Env: os.Getenv(TemplateEnvVar)}
+	if templateEnv != nil {
+		td.Env = *templateEnv
+	}
+	templateMu.Unlock()
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, td); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseTemplate renders the template file and parses the result by adapter
+func parseTemplate(adapter Config, name string, data []byte) (Provider, error) {
+	// the adapter renders data itself, which mustn't be rendered twice
+	if ini, ok := adapter.(*IniConfig); ok && ini.template {
+		return ini.parseReader(bytes.NewReader(data), name)
+	}
+	data, err := RenderTemplate(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.ParseData(data)
+}
+
+// trimTemplateExt removes TemplateExt from the file name, so app.ini.tmpl is app.ini
+func trimTemplateExt(fileName string) string {
+	if IsTemplate(fileName) {
+		return fileName[:len(fileName)-len(TemplateExt)]
+	}
+	return fileName
+}
@@ -0,0 +1,115 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+const appTemplate = `name = {{ env "APP_NAME" | default "app" }}
+host = {{ hostname }}
+{{ if eq .Env "prod" }}
+debug = off
+{{ else }}
+debug = on
+{{ end }}
+`
+
+func TestTemplate(t *testing.T) {
+	defer func() { templateEnv = nil }()
+	t.Setenv(TemplateEnvVar, "prod")
+	hostname, _ := os.Hostname()
+
+	dir, err := ioutil.TempDir("", "config-template")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "app.ini.tmpl")
+	if err := ioutil.WriteFile(file, []byte(appTemplate), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// test Load by extension
+	c, err := Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Get("name") != "app" || c.Get("host") != hostname || c.Get("debug") != "off" {
+		t.Fatalf("rendered config is %v", Settings(c))
+	}
+	if s := Settings(c); s[0].Source != file {
+		t.Fatalf("source is %s", s[0].Source)
+	}
+
+	// test env and SetTemplateEnv
+	t.Setenv("APP_NAME", "shop")
+	SetTemplateEnv("dev")
+	if c, err = NewConfig("ini", file); err != nil {
+		t.Fatal(err)
+	}
+	if c.Get("name") != "shop" || c.Get("debug") != "on" {
+		t.Fatalf("rendered config is %v", Settings(c))
+	}
+
+	// test other adapters and LoadDir
+	fsys := fstest.MapFS{
+		"conf/db.json.tmpl": {Data: []byte(`{"host": "{{ env "APP_NAME" }}.db"}`)},
+		"conf/cache.ini":    {Data: []byte("host = {{ raw }}\n")},
+	}
+	if c, err = LoadDirFS(fsys, "conf"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Get("db.host") != "shop.db" || c.Get("cache.host") != "{{ raw }}" {
+		t.Fatalf("dir config is %v", Settings(c))
+	}
+
+	// test WithTemplate, the data is rendered once
+	t.Setenv("APP_NAME", "{{ hostname }}")
+	ini := NewIniConfig(WithTemplate())
+	if c, err = ini.ParseData([]byte(appTemplate)); err != nil {
+		t.Fatal(err)
+	}
+	if c.Get("name") != "{{ hostname }}" {
+		t.Fatalf("name is %s", c.Get("name"))
+	}
+	if c, err = parseTemplate(ini, "app.ini.tmpl", []byte(appTemplate)); err != nil || c.Get("name") != "{{ hostname }}" {
+		t.Fatalf("template is rendered twice: %v", err)
+	}
+	if c, err = NewIniConfig().ParseData([]byte(appTemplate)); err != nil || c.Get("host") != "{{ hostname }}" {
+		t.Fatalf("data without WithTemplate shouldn't be rendered: %v", err)
+	}
+
+	// test errors
+	for _, data := range []string{
+		`name = {{ readFile "/etc/passwd" }}`,
+		`name = {{ .Missing }}`,
+		`name = {{ if }}`,
+	} {
+		if _, err := RenderTemplate("bad.ini.tmpl", []byte(data)); err == nil {
+			t.Fatalf("%s should fail", data)
+		}
+	}
+	if !IsTemplate("APP.INI.TMPL") || IsTemplate("app.ini") {
+		t.Fatal("IsTemplate is wrong")
+	}
+	if name, ok := AdapterByExtension("app.yml.tmpl"); !ok || name != "yaml" {
+		t.Fatalf("adapter of app.yml.tmpl is %s", name)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectAdapter(t *testing.T) {
+	cases := []struct {
+		file, data, want string
+	}{
+		{"app.ini", "", "ini"},
+		{"app.CONF", "", "ini"},
+		{"app.yml", "", "yaml"},
+		{"app.toml", "", "toml"},
+		{"app", "\n; comment\n[db]\nhost = localhost\n", "ini"},
+		{"app", "host = localhost\n", "ini"},
+		{"app", "\ufeff  {\"host\": \"localhost\"}", "json"},
+		{"app", "# comment\ndb:\n  host: localhost\n", "yaml"},
+		{"app", "---\nhost: localhost\n", "yaml"},
+		{"app", "url: http://localhost/?a=b\n", "yaml"},
+	}
+	for _, c := range cases {
+		name, err := DetectAdapter(c.file, []byte(c.data))
+		if err != nil || name != c.want {
+			t.Fatalf("detect %s %q: got %s %v, want %s", c.file, c.data, name, err, c.want)
+		}
+	}
+	if _, err := DetectAdapter("app", []byte("<config/>")); err == nil {
+		t.Fatal("xml adapter isn't registered")
+	}
+
+	// test Register options
+	Register("xml", &IniConfig{}, WithExtensions(".XML"), WithSniffer(func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte("<?xml"))
+	}))
+	defer func() {
+		delete(adapters, "xml")
+		delete(extensions, ".xml")
+		sniffers = sniffers[:len(sniffers)-1]
+	}()
+	if name, _ := DetectAdapter("app", []byte("<?xml version=\"1.0\"?>")); name != "xml" {
+		t.Fatalf("sniff xml: got %s", name)
+	}
+	if name, _ := AdapterByExtension("app.xml"); name != "xml" {
+		t.Fatalf("xml extension: got %s", name)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("extension .ini is claimed by ini")
+			}
+		}()
+		Register("ini2", &IniConfig{}, WithExtensions(".ini"))
+	}()
+	delete(adapters, "ini2")
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config-load")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"app.yml":   "db:\n  host: localhost\n",
+		"app.local": `{"db": {"host": "localhost"}}`,
+	}
+	for name, data := range files {
+		file := filepath.Join(dir, name)
+		ioutil.WriteFile(file, []byte(data), 0644)
+		p, err := Load(file)
+		if err != nil {
+			t.Fatalf("load %s: %v", name, err)
+		}
+		if v := p.Get("db.host"); v != "localhost" {
+			t.Fatalf("load %s: db.host is %s", name, v)
+		}
+	}
+	if _, err := Load(filepath.Join(dir, "aaa.ini")); err == nil {
+		t.Fatal("aaa.ini shouldn't exist")
+	}
+}
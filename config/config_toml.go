@@ -0,0 +1,300 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// TOMLConfig parses the table subset of toml:
+// keys before the first table belong to default section, tables are sections,
+// dotted keys and sub tables are flattened into keys joined by attributeDivision,
+// single line arrays are joined by ";".
+// Arrays of tables, inline tables and multi-line strings are not supported.
+type TOMLConfig struct {
+}
+
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+// Parse parse toml file
+func (t *TOMLConfig) Parse(fileName string) (Provider, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := t.ParseReader(f)
+	return withSource(p, err, fileName)
+}
+
+// ParseFS parse toml file in fsys
+func (t *TOMLConfig) ParseFS(fsys fs.FS, name string) (Provider, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := t.ParseReader(f)
+	return withSource(p, err, name)
+}
+
+// ParseData parse toml bytes data
+func (t *TOMLConfig) ParseData(data []byte) (Provider, error) {
+	return t.ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parse toml data from reader line by line
+func (t *TOMLConfig) ParseReader(r io.Reader) (Provider, error) {
+	var (
+		b       = new(documentBuilder)
+		comment bytes.Buffer
+		section = defaultSection
+		prefix  string
+		lineNum int
+	)
+	lines := newLineReader(r)
+	for {
+		text, err := lines.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		lineNum++
+		line := strings.TrimSpace(string(text))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if comment.Len() > 0 {
+				comment.WriteByte('\n')
+			}
+			comment.WriteString(line[1:])
+			continue
+		}
+		if strings.HasPrefix(line, "[[") {
+			return nil, fmt.Errorf("toml parse: line %d: array of tables is not supported", lineNum)
+		}
+		if strings.HasPrefix(line, "[") {
+			end := strings.LastIndex(line, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("toml parse: line %d: unterminated table %s", lineNum, line)
+			}
+			names, err := splitTOMLKey(line[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("toml parse: line %d: %v", lineNum, err)
+			}
+			section, prefix = names[0], ""
+			if len(names) > 1 {
+				prefix = strings.Join(names[1:], attributeDivision) + attributeDivision
+			}
+			s := b.section(section)
+			if comment.Len() > 0 && prefix == "" {
+				s.Comment = comment.String()
+			}
+			comment.Reset()
+			continue
+		}
+		i := tomlAssign(line)
+		if i < 0 {
+			return nil, fmt.Errorf("toml parse: line %d: missing '=' in %s", lineNum, line)
+		}
+		names, err := splitTOMLKey(line[:i])
+		if err != nil {
+			return nil, fmt.Errorf("toml parse: line %d: %v", lineNum, err)
+		}
+		value, err := parseTOMLValue(strings.TrimSpace(line[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("toml parse: line %d: %v", lineNum, err)
+		}
+		b.set(section, prefix+strings.Join(names, attributeDivision), value, comment.String())
+		comment.Reset()
+	}
+	return newContainerDocument(&b.doc), nil
+}
+
+// Marshal writes the document as toml bytes data,
+// numbers and bools are written bare, other values are written as strings.
+func (t *TOMLConfig) Marshal(doc *Document) ([]byte, error) {
+	if err := checkSectionConflict("toml", doc); err != nil {
+		return nil, err
+	}
+	writeComment := func(buf *bytes.Buffer, comment string) {
+		if comment == "" {
+			return
+		}
+		for _, line := range strings.Split(comment, lineBreak) {
+			buf.WriteString("#" + line + lineBreak)
+		}
+	}
+	key := func(k string) string {
+		if tomlBareKey.MatchString(k) {
+			return k
+		}
+		return quoteString(k)
+	}
+	writeSection := func(buf *bytes.Buffer, section Section, header bool) {
+		writeComment(buf, section.Comment)
+		if header {
+			buf.WriteString("[" + key(section.Name) + "]" + lineBreak)
+		}
+		for _, entry := range section.Entries {
+			writeComment(buf, entry.Comment)
+			buf.WriteString(key(entry.Key) + " = " + literalValue(entry.Value) + lineBreak)
+		}
+		buf.WriteString(lineBreak)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	// keys of default section must appear before any table
+	for _, section := range doc.Sections {
+		if section.Name == defaultSection {
+			writeSection(buf, section, false)
+		}
+	}
+	for _, section := range doc.Sections {
+		if section.Name != defaultSection {
+			writeSection(buf, section, true)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// tomlAssign retrieves the index of '=' which is out of quoted key
+func tomlAssign(line string) int {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"', '\'':
+			end := closingQuote(line[i:])
+			if end < 0 {
+				return -1
+			}
+			i += end
+		case '=':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitTOMLKey splits bare, quoted and dotted key into names
+func splitTOMLKey(s string) ([]string, error) {
+	var names []string
+	s = strings.TrimSpace(s)
+	for {
+		if s == "" {
+			return nil, fmt.Errorf("empty key")
+		}
+		var name string
+		if s[0] == '"' || s[0] == '\'' {
+			end := closingQuote(s)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated key %s", s)
+			}
+			v, err := unquoteTOML(s[:end+1])
+			if err != nil {
+				return nil, err
+			}
+			name, s = strings.ToLower(v), strings.TrimSpace(s[end+1:])
+		} else {
+			end := strings.IndexByte(s, '.')
+			if end < 0 {
+				end = len(s)
+			}
+			name, s = strings.TrimSpace(s[:end]), strings.TrimSpace(s[end:])
+			if !tomlBareKey.MatchString(name) {
+				return nil, fmt.Errorf("invalid key %s", name)
+			}
+			name = strings.ToLower(name)
+		}
+		names = append(names, name)
+		if s == "" {
+			return names, nil
+		}
+		if s[0] != '.' {
+			return nil, fmt.Errorf("unexpected %s in key", s)
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+}
+
+// parseTOMLValue parses string, array and the other scalar values
+func parseTOMLValue(s string) (string, error) {
+	if s == "" {
+		return "", fmt.Errorf("missing value")
+	}
+	switch s[0] {
+	case '"', '\'':
+		if strings.HasPrefix(s, `"""`) || strings.HasPrefix(s, "'''") {
+			return "", fmt.Errorf("multi-line string is not supported")
+		}
+		end := closingQuote(s)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string %s", s)
+		}
+		if tail := strings.TrimSpace(s[end+1:]); tail != "" && !strings.HasPrefix(tail, "#") {
+			return "", fmt.Errorf("unexpected %s after value", tail)
+		}
+		return unquoteTOML(s[:end+1])
+	case '[':
+		end := strings.LastIndex(s, "]")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated array %s", s)
+		}
+		flow, err := splitFlow(s[1:end])
+		if err != nil {
+			return "", err
+		}
+		var items []string
+		for _, item := range flow {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			v, err := parseTOMLValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, v)
+		}
+		return strings.Join(items, ";"), nil
+	case '{':
+		return "", fmt.Errorf("inline table is not supported")
+	}
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
+
+// unquoteTOML unquotes basic or literal toml string
+func unquoteTOML(s string) (string, error) {
+	if s[0] == '\'' {
+		return s[1 : len(s)-1], nil
+	}
+	return unquoteString(s)
+}
+
+func init() {
+	// toml content can't be told from ini, so it is only claimed by extension
+	Register("toml", &TOMLConfig{}, WithExtensions(".toml"))
+}
@@ -0,0 +1,162 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"container/list"
+	"maps"
+)
+
+// Tx is the pending state of Update, reads see the writes before them
+type Tx interface {
+	Get(key string) string
+	Has(key string) bool
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// tx applies the changes to container, which is the copy of Update
+type tx struct {
+	c       *Container
+	changed bool
+}
+
+func (t *tx) Get(key string) string {
+	return t.c.Get(key)
+}
+
+func (t *tx) Has(key string) bool {
+	return t.c.Has(key)
+}
+
+func (t *tx) Set(key, value string) error {
+	if err := t.c.set(key, value); err != nil {
+		return err
+	}
+	t.changed = true
+	return nil
+}
+
+func (t *tx) Delete(key string) error {
+	if err := t.c.delete(key); err != nil {
+		return err
+	}
+	t.changed = true
+	return nil
+}
+
+// Update applies the changes made by fn atomically, readers see all or none of them.
+// fn changes a copy of c through tx, the copy replaces the data of c when fn returns nil
+// and all validators accept it, otherwise nothing changes and the error is returned.
+// Update, Set, Delete and the reloads of source are serialized,
+// and watchers are notified once after the changes are applied.
+// fn mustn't call Update, Set or Delete of c.
+func (c *Container) Update(fn func(tx Tx) error) error {
+	c.update.Lock()
+	changed, err := c.commit(fn)
+	c.update.Unlock()
+	if changed {
+		c.notify()
+	}
+	return err
+}
+
+// change applies fn like Update, the copy is skipped when c has no validators
+func (c *Container) change(fn func(tx Tx) error) error {
+	c.update.Lock()
+	var (
+		changed bool
+		err     error
+	)
+	if c.validated() {
+		changed, err = c.commit(fn)
+	} else {
+		t := &tx{c: c}
+		err = fn(t)
+		changed = t.changed
+	}
+	c.update.Unlock()
+	if changed {
+		c.notify()
+	}
+	return err
+}
+
+// commit runs fn on a copy of c and swaps it in when validators accept it,
+// the caller must hold c.update, so c isn't changed meanwhile.
+func (c *Container) commit(fn func(tx Tx) error) (bool, error) {
+	t := &tx{c: c.clone()}
+	if err := fn(t); err != nil {
+		return false, err
+	}
+	if !t.changed {
+		return false, nil
+	}
+	c.RLock()
+	validators := c.validators
+	c.RUnlock()
+	for _, validate := range validators {
+		if err := validate(t.c); err != nil {
+			return false, err
+		}
+	}
+	c.swap(t.c)
+	return true, nil
+}
+
+// AddValidator registers fn to check the result of Update, Set and Delete,
+// the change is rejected when fn returns error. fn receives the pending result, which mustn't be changed.
+// The reloads of source aren't validated, they replace the data as a whole.
+func (c *Container) AddValidator(fn func(p Provider) error) {
+	c.Lock()
+	defer c.Unlock()
+	c.validators = append(c.validators, fn)
+}
+
+// validated reports whether c has validators
+func (c *Container) validated() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return len(c.validators) > 0
+}
+
+// clone copies the data and settings of c, the watchers and validators aren't copied
+func (c *Container) clone() *Container {
+	c.RLock()
+	defer c.RUnlock()
+	n := newContainer()
+	for section, values := range c.data {
+		n.data[section] = maps.Clone(values)
+	}
+	for e := c.list.Front(); e != nil; e = e.Next() {
+		for section, keyList := range e.Value.(map[string]*list.List) {
+			keys := list.New()
+			for k := keyList.Front(); k != nil; k = k.Next() {
+				keys.PushBack(k.Value)
+			}
+			n.list.PushBack(map[string]*list.List{section: keys})
+		}
+	}
+	maps.Copy(n.sectionComment, c.sectionComment)
+	maps.Copy(n.attributeComment, c.attributeComment)
+	maps.Copy(n.sources, c.sources)
+	maps.Copy(n.folded, c.folded)
+	n.parent, n.sep = c.parent, c.sep
+	n.source = c.source
+	n.charset, n.bom = c.charset, c.bom
+	n.caseMode = c.caseMode
+	n.sectionDivision, n.attributeDivision = c.sectionDivision, c.attributeDivision
+	return n
+}
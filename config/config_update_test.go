@@ -0,0 +1,197 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdate(t *testing.T) {
+	p, _ := NewConfigData("ini", []byte("[db]\nhost = master\nport = 3306\n"))
+	errPort := errors.New("db.port should be a number")
+	p.AddValidator(func(p Provider) error {
+		if _, err := p.Int("db.port"); err != nil {
+			return errPort
+		}
+		if !p.Has("db.host") {
+			return errors.New("db.host is required")
+		}
+		return nil
+	})
+	var r recorder
+	p.Watch("db.host", r.add)
+
+	// test changes are applied together
+	err := p.Update(func(tx Tx) error {
+		if err := tx.Set("db.host", "slave"); err != nil {
+			return err
+		}
+		if tx.Get("db.host") != "slave" {
+			t.Fatal("tx should read its writes")
+		}
+		tx.Set("db.host", "backup")
+		return tx.Set("db.port", "3307")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Get("db.host") != "backup" || p.Get("db.port") != "3307" {
+		t.Fatalf("db is %s:%s", p.Get("db.host"), p.Get("db.port"))
+	}
+	waitFor(t, func() bool { return len(r.get()) == 1 })
+	if got := r.get(); !reflect.DeepEqual(got, []string{"backup"}) {
+		t.Fatalf("watcher got %v", got)
+	}
+	if s := Settings(p); s[0].Source != sourceSet {
+		t.Fatalf("source is %s", s[0].Source)
+	}
+
+	// test rejected changes aren't applied
+	err = p.Update(func(tx Tx) error {
+		tx.Set("db.host", "other")
+		return tx.Set("db.port", "aaa")
+	})
+	if err != errPort {
+		t.Fatalf("error is %v", err)
+	}
+	errStop := errors.New("stop")
+	if err := p.Update(func(tx Tx) error {
+		tx.Set("db.host", "other")
+		return errStop
+	}); err != errStop {
+		t.Fatalf("error is %v", err)
+	}
+	if err := p.Set("db.port", "bbb"); err != errPort {
+		t.Fatalf("Set error is %v", err)
+	}
+	if err := p.Delete("db.host"); err == nil {
+		t.Fatal("db.host shouldn't be deleted")
+	}
+	if err := p.Update(func(tx Tx) error { return tx.Delete("db.user") }); err == nil {
+		t.Fatal("db.user doesn't exist")
+	}
+	if p.Get("db.host") != "backup" || p.Get("db.port") != "3307" {
+		t.Fatalf("db is %s:%s", p.Get("db.host"), p.Get("db.port"))
+	}
+	if err := p.Set("db.user", "root"); err != nil || p.Get("db.user") != "root" {
+		t.Fatalf("Set db.user: %v", err)
+	}
+	if err := p.Delete("db.user"); err != nil || p.Has("db.user") {
+		t.Fatalf("Delete db.user: %v", err)
+	}
+}
+
+func TestUpdateConcurrent(t *testing.T) {
+	for _, validated := range []bool{false, true} {
+		p, _ := NewConfigData("ini", []byte("count = 0\nleft = 0\nright = 0\n"))
+		if validated {
+			p.AddValidator(func(p Provider) error {
+				if p.Get("left") != p.Get("right") {
+					return errors.New("left and right should be equal")
+				}
+				return nil
+			})
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(3)
+			// updates are serialized, so no increment is lost
+			go func() {
+				defer wg.Done()
+				p.Update(func(tx Tx) error {
+					n, _ := strconv.Atoi(tx.Get("count"))
+					return tx.Set("count", strconv.Itoa(n+1))
+				})
+			}()
+			// Set isn't lost by the update meanwhile
+			go func(i int) {
+				defer wg.Done()
+				if err := p.Set("set"+strconv.Itoa(i), "on"); err != nil {
+					t.Error(err)
+				}
+			}(i)
+			// readers never see half of update
+			go func(i int) {
+				defer wg.Done()
+				p.Update(func(tx Tx) error {
+					tx.Set("left", strconv.Itoa(i))
+					return tx.Set("right", strconv.Itoa(i))
+				})
+				for _, s := range p.Document().Sections {
+					if s.Entries[1].Value != s.Entries[2].Value {
+						t.Errorf("left %s and right %s", s.Entries[1].Value, s.Entries[2].Value)
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		if p.Get("count") != "10" {
+			t.Fatalf("validated %v: count is %s", validated, p.Get("count"))
+		}
+		for i := 0; i < 10; i++ {
+			if !p.Has("set" + strconv.Itoa(i)) {
+				t.Fatalf("validated %v: set%d is lost", validated, i)
+			}
+		}
+	}
+}
+
+func TestUpdateReload(t *testing.T) {
+	s := new(configServer)
+	s.set(`{"name": "remote"}`)
+	server := httptest.NewServer(s)
+	defer server.Close()
+	r, err := NewRemote(server.URL, "json", WithHeader("Authorization", "token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := r.Provider()
+
+	// the reload waits for the running update, which isn't applied over the reloaded data
+	started, refreshed := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(refreshed)
+		<-started
+		s.set(`{"name": "reloaded"}`)
+		if _, err := r.Refresh(); err != nil {
+			t.Error(err)
+		}
+	}()
+	err = p.Update(func(tx Tx) error {
+		close(started)
+		tx.Set("name", "local")
+		tx.Set("db.host", "localhost")
+		select {
+		case <-refreshed:
+			t.Error("reload shouldn't be applied during update")
+		case <-time.After(50 * time.Millisecond):
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-refreshed
+	if p.Get("name") != "reloaded" || p.Has("db.host") {
+		t.Fatalf("config is %v", Settings(p))
+	}
+}
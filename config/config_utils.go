@@ -15,8 +15,16 @@
 package config
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // ParseBool convert value to bool
@@ -51,3 +59,277 @@ func ParseBool(value interface{}) (bool, error) {
 	}
 	return false, fmt.Errorf("parsing %q: invalid syntax", value)
 }
+
+// byteUnits lists the multiple of size units,
+// php.ini style K/M/G and IEC KiB/MiB/GiB are 1024 based, SI KB/MB/GB are 1000 based.
+var byteUnits = map[string]float64{
+	"":    1,
+	"B":   1,
+	"K":   1 << 10,
+	"M":   1 << 20,
+	"G":   1 << 30,
+	"T":   1 << 40,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+}
+
+// ParseBytes convert size like "128M", "10MB" or "1.5GiB" to the number of bytes
+func ParseBytes(value string) (int64, error) {
+	s := strings.TrimSpace(value)
+	i := strings.LastIndexAny(s, "0123456789.")
+	if i < 0 {
+		return 0, fmt.Errorf("parsing %q: invalid syntax", value)
+	}
+	unit, ok := byteUnits[strings.ToUpper(strings.TrimSpace(s[i+1:]))]
+	if !ok {
+		return 0, fmt.Errorf("parsing %q: unknown unit", value)
+	}
+	if n, err := strconv.ParseInt(s[:i+1], 10, 64); err == nil && unit == 1 {
+		return n, nil
+	}
+	n, err := strconv.ParseFloat(s[:i+1], 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("parsing %q: invalid syntax", value)
+	}
+	size := n * unit
+	// float64(math.MaxInt64) rounds up to 1<<63, which overflows int64
+	if size >= 1<<63 {
+		return 0, fmt.Errorf("parsing %q: value out of range", value)
+	}
+	return int64(size), nil
+}
+
+// ParseDuration convert value like "30s" or "1h30m" to duration,
+// number without unit like php.ini's "30" is seconds.
+func ParseDuration(value string) (time.Duration, error) {
+	s := strings.TrimSpace(value)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// defaultTimeLayouts are tried in order by ParseTime when no layout is given
+var defaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ParseTime convert value to time by the first layout matched,
+// RFC3339, "2006-01-02 15:04:05" and "2006-01-02" are tried when layouts is empty.
+func ParseTime(value string, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = defaultTimeLayouts
+	}
+	s := strings.TrimSpace(value)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("parsing %q: doesn't match layouts %q", value, layouts)
+}
+
+// cutQuoted cuts the first item of s before sep and trims space around it,
+// sep inside the item quoted by ' or " doesn't end it, and the quotes are removed.
+func cutQuoted(s, sep string) (item, rest string, found bool) {
+	s = strings.TrimLeft(s, " \t")
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		if end := strings.IndexByte(s[1:], s[0]); end >= 0 {
+			item, s = s[1:end+1], s[end+2:]
+			tail, rest, found := strings.Cut(s, sep)
+			return item + strings.TrimSpace(tail), rest, found
+		}
+	}
+	item, rest, found = strings.Cut(s, sep)
+	return strings.TrimSpace(item), rest, found
+}
+
+// splitQuoted splits s by sep, see cutQuoted
+func splitQuoted(s, sep string) []string {
+	var items []string
+	for {
+		item, rest, found := cutQuoted(s, sep)
+		items = append(items, item)
+		if !found {
+			return items
+		}
+		s = rest
+	}
+}
+
+// splitMap splits s like "k1:v1,k2:v2" by pairSep and valueSep, see cutQuoted
+func splitMap(s, pairSep, valueSep string) (map[string]string, error) {
+	values := make(map[string]string)
+	for strings.TrimSpace(s) != "" {
+		key, rest, found := cutQuoted(s, valueSep)
+		if !found {
+			return nil, fmt.Errorf("parsing %q: %q should be key%svalue", s, key, valueSep)
+		}
+		values[key], s, _ = cutQuoted(rest, pairSep)
+	}
+	return values, nil
+}
+
+// documentBuilder helps adapters to build document in original sort
+type documentBuilder struct {
+	doc   Document
+	index map[string]int
+}
+
+// section retrieves the named section, appends it when it is not set
+func (b *documentBuilder) section(name string) *Section {
+	if b.index == nil {
+		b.index = make(map[string]int)
+	}
+	i, ok := b.index[name]
+	if !ok {
+		i = len(b.doc.Sections)
+		b.index[name] = i
+		b.doc.Sections = append(b.doc.Sections, Section{Name: name})
+	}
+	return &b.doc.Sections[i]
+}
+
+// set appends an entry to the section
+func (b *documentBuilder) set(section, key, value, comment string) {
+	s := b.section(section)
+	s.Entries = append(s.Entries, Entry{Key: key, Value: value, Comment: comment})
+}
+
+// numberLiteral matches the numbers which are valid in json, yaml and toml
+var numberLiteral = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// literalValue writes the number or bool value bare, so its type is kept by json, yaml and toml,
+// other values are quoted.
+func literalValue(s string) string {
+	if s == "true" || s == "false" {
+		return s
+	}
+	if numberLiteral.MatchString(s) {
+		var err error
+		if strings.ContainsAny(s, ".eE") {
+			_, err = strconv.ParseFloat(s, 64)
+		} else {
+			// toml integers are 64 bits
+			_, err = strconv.ParseInt(s, 10, 64)
+		}
+		if err == nil {
+			return s
+		}
+	}
+	return quoteString(s)
+}
+
+// quoteString quotes s as a double quoted string which is valid in json, yaml and toml
+func quoteString(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// unquoteString is the reverse of quoteString, it also accepts \uXXXX escapes
+func unquoteString(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("parsing %s: invalid quoted string", s)
+	}
+	var v string
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return "", fmt.Errorf("parsing %s: %v", s, err)
+	}
+	return v, nil
+}
+
+// checkSectionConflict reports the key of default section which has the same name as a section,
+// they can't be both written as the top level keys of format.
+func checkSectionConflict(format string, doc *Document) error {
+	names := make(map[string]bool)
+	for _, section := range doc.Sections {
+		names[section.Name] = true
+	}
+	for _, section := range doc.Sections {
+		if section.Name != defaultSection {
+			continue
+		}
+		for _, entry := range section.Entries {
+			if names[entry.Key] {
+				return fmt.Errorf("%s marshal: key %s conflicts with section %s", format, entry.Key, entry.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// lineReader reads lines of any length from reader,
+// bufio.Scanner and bufio.Reader.ReadLine split the long lines.
+type lineReader struct {
+	buf *bufio.Reader
+}
+
+// newLineReader returns a lineReader which skips the utf-8 bom
+func newLineReader(r io.Reader) *lineReader {
+	buf := bufio.NewReader(r)
+	if bom, err := buf.Peek(3); err == nil && bytes.Equal(bom, []byte{239, 187, 191}) {
+		buf.Discard(3)
+	}
+	return &lineReader{buf: buf}
+}
+
+// next retrieves the next line without line break, io.EOF is returned after the last line
+func (lr *lineReader) next() ([]byte, error) {
+	line, err := lr.buf.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(line, "\r\n"), nil
+}
+
+// firstLine retrieves the first line which isn't empty or comment
+func firstLine(data []byte, commentPrefixes string) []byte {
+	for len(data) > 0 {
+		var line []byte
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			line, data = data, nil
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || strings.IndexByte(commentPrefixes, line[0]) >= 0 {
+			continue
+		}
+		return line
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// WatchOption configures how the callback of Watch is delivered
+type WatchOption func(w *watcher)
+
+// DeliverTo sends the callbacks to ch instead of calling them,
+// so they can run on the goroutine receiving ch, like the main loop of application.
+// The callbacks are sent in order, and the other callbacks of the provider wait until ch receives.
+func DeliverTo(ch chan<- func()) WatchOption {
+	return func(w *watcher) {
+		w.ch = ch
+	}
+}
+
+// watcher is a subscription of key
+type watcher struct {
+	key   string
+	fn    func(value string)
+	ch    chan<- func()
+	value string
+	ok    bool
+}
+
+// watchState holds the watchers of container
+type watchState struct {
+	sync.Mutex
+	watchers []*watcher
+	// children fall back to this container, they are notified when it changes
+	children   []*Container
+	dispatcher dispatcher
+}
+
+// Watch calls fn with the new value after the effective value of key changes,
+// by Set, Delete, reload of the source, or the change of parent.
+// The empty value is delivered when the key is deleted.
+// The callbacks of a provider are called one by one in the order of changes on another goroutine,
+// never under the lock of provider. Call cancel to stop watching.
+func (c *Container) Watch(key string, fn func(value string), opts ...WatchOption) (cancel func()) {
+	w := &watcher{key: key, fn: fn}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.value, w.ok, _ = c.resolve(key)
+	c.watch.Lock()
+	c.watch.watchers = append(c.watch.watchers, w)
+	c.watch.Unlock()
+	return func() {
+		c.watch.Lock()
+		defer c.watch.Unlock()
+		for i, v := range c.watch.watchers {
+			if v == w {
+				c.watch.watchers = append(c.watch.watchers[:i:i], c.watch.watchers[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// WatchInt is like Watch, but fn receives the integer value,
+// and isn't called when the value can't be parsed.
+func (c *Container) WatchInt(key string, fn func(value int), opts ...WatchOption) (cancel func()) {
+	return c.Watch(key, func(value string) {
+		if v, err := strconv.Atoi(value); err == nil {
+			fn(v)
+		}
+	}, opts...)
+}
+
+// WatchDuration is like Watch, but fn receives the duration value like "1m30s" or "90",
+// and isn't called when the value can't be parsed.
+func (c *Container) WatchDuration(key string, fn func(value time.Duration), opts ...WatchOption) (cancel func()) {
+	return c.Watch(key, func(value string) {
+		if v, err := ParseDuration(value); err == nil {
+			fn(v)
+		}
+	}, opts...)
+}
+
+// addChild registers the container which falls back to c
+func (c *Container) addChild(child *Container) {
+	c.watch.Lock()
+	defer c.watch.Unlock()
+	c.watch.children = append(c.watch.children, child)
+}
+
+// notify delivers the changed values to watchers of c and its children,
+// the caller mustn't hold the lock of c.
+func (c *Container) notify() {
+	c.watch.Lock()
+	for _, w := range c.watch.watchers {
+		value, ok, _ := c.resolve(w.key)
+		if value == w.value && ok == w.ok {
+			continue
+		}
+		w.value, w.ok = value, ok
+		fn, ch := w.fn, w.ch
+		if ch == nil {
+			c.watch.dispatcher.push(func() { fn(value) })
+		} else {
+			c.watch.dispatcher.push(func() { ch <- func() { fn(value) } })
+		}
+	}
+	children := append([]*Container(nil), c.watch.children...)
+	c.watch.Unlock()
+	for _, child := range children {
+		child.notify()
+	}
+}
+
+// dispatcher runs the queued callbacks in order on one goroutine,
+// which exits when the queue is empty.
+type dispatcher struct {
+	mu      sync.Mutex
+	queue   []func()
+	running bool
+}
+
+func (d *dispatcher) push(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queue = append(d.queue, fn)
+	if !d.running {
+		d.running = true
+		go d.run()
+	}
+}
+
+func (d *dispatcher) run() {
+	for {
+		d.mu.Lock()
+		if len(d.queue) == 0 {
+			d.running = false
+			d.mu.Unlock()
+			return
+		}
+		fn := d.queue[0]
+		d.queue[0] = nil
+		d.queue = d.queue[1:]
+		d.mu.Unlock()
+		fn()
+	}
+}
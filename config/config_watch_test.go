@@ -0,0 +1,215 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects the values delivered to watchers
+type recorder struct {
+	sync.Mutex
+	values []string
+}
+
+func (r *recorder) add(value string) {
+	r.Lock()
+	defer r.Unlock()
+	r.values = append(r.values, value)
+}
+
+func (r *recorder) get() []string {
+	r.Lock()
+	defer r.Unlock()
+	return append([]string(nil), r.values...)
+}
+
+func TestWatch(t *testing.T) {
+	p, _ := NewConfigData("ini", []byte("[db]\npool_size = 10\ntimeout = 1s\n"))
+
+	// test changes are delivered in order
+	var r recorder
+	p.Watch("db.pool_size", r.add)
+	for i := 11; i <= 20; i++ {
+		p.Set("db.pool_size", strconv.Itoa(i))
+	}
+	p.Set("db.pool_size", "20")
+	p.Set("db.timeout", "2s")
+	p.Delete("db.pool_size")
+	want := []string{"11", "12", "13", "14", "15", "16", "17", "18", "19", "20", ""}
+	waitFor(t, func() bool { return len(r.get()) == len(want) })
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("values are %v", got)
+	}
+
+	// test typed watchers
+	var (
+		mu       sync.Mutex
+		size     int
+		timeouts []time.Duration
+	)
+	p.WatchInt("db.pool_size", func(v int) {
+		mu.Lock()
+		size = v
+		mu.Unlock()
+	})
+	cancel := p.WatchDuration("db.timeout", func(v time.Duration) {
+		mu.Lock()
+		timeouts = append(timeouts, v)
+		mu.Unlock()
+	})
+	p.Set("db.pool_size", "aaa")
+	p.Set("db.pool_size", "30")
+	p.Set("db.timeout", "90")
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return size == 30 && len(timeouts) == 1 && timeouts[0] == 90*time.Second
+	})
+	cancel()
+	p.Set("db.timeout", "5s")
+
+	// test callbacks aren't called under the lock
+	done := make(chan bool)
+	p.Watch("db.timeout", func(v string) {
+		p.Set("db.copy", v)
+		done <- true
+	})
+	p.Set("db.timeout", "6s")
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback is blocked")
+	}
+	mu.Lock()
+	if len(timeouts) != 1 {
+		t.Fatalf("canceled watcher got %v", timeouts)
+	}
+	mu.Unlock()
+
+	// test DeliverTo
+	ch := make(chan func(), 1)
+	var delivered string
+	p.Watch("db.pool_size", func(v string) { delivered = v }, DeliverTo(ch))
+	p.Set("db.pool_size", "40")
+	select {
+	case fn := <-ch:
+		fn()
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback isn't delivered")
+	}
+	if delivered != "40" {
+		t.Fatalf("delivered %s", delivered)
+	}
+}
+
+func TestWatchLayers(t *testing.T) {
+	global := newContainer()
+	global.Set("db.host", "localhost")
+	app := NewApp(global)
+	app.SetModule("admin", newContainer())
+	admin := app.Module("admin")
+
+	var r recorder
+	admin.Watch("db.host", r.add)
+	global.Set("db.host", "10.0.0.1")
+	admin.Set("db.host", "10.0.0.2")
+	// hidden by module value
+	global.Set("db.host", "10.0.0.3")
+	admin.Delete("db.host")
+	want := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	waitFor(t, func() bool { return len(r.get()) == len(want) })
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("values are %v", got)
+	}
+
+	// test reload of source
+	kv := NewMemoryKV()
+	kv.Put("/app/db/host", "localhost")
+	s, err := NewKVSource(context.Background(), kv, "/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	var kr recorder
+	s.Provider().Watch("db.host", kr.add)
+	kv.Put("/app/db/host", "10.0.0.1")
+	waitFor(t, func() bool { return len(kr.get()) == 1 && kr.get()[0] == "10.0.0.1" })
+}
+
+func TestWatchKVSource(t *testing.T) {
+	kv := NewMemoryKV()
+	kv.Put("/app/db/host", "localhost")
+	s, err := NewKVSource(context.Background(), kv, "/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	p := s.Provider()
+	var host, user recorder
+	p.Watch("db.host", host.add)
+	p.Watch("db.user", user.add)
+
+	// test rejected changes fire no event
+	if err := p.Delete("db.user"); err == nil {
+		t.Fatal("db.user doesn't exist")
+	}
+	if err := p.Set("", "root"); err == nil {
+		t.Fatal("key is empty")
+	}
+
+	// test puts and deletes of backend fire one event each
+	kv.Put("/app/db/host", "10.0.0.1")
+	kv.Delete("/app/db/host")
+	want := []string{"10.0.0.1", ""}
+	waitFor(t, func() bool { return len(host.get()) == len(want) })
+	time.Sleep(10 * time.Millisecond)
+	if got := host.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("db.host values are %v", got)
+	}
+	if got := user.get(); len(got) != 0 {
+		t.Fatalf("db.user values are %v", got)
+	}
+
+	// test changes rejected by validators fire no event, the backend isn't validated
+	kv.Put("/app/db/port", "3306")
+	waitFor(t, func() bool { return p.Has("db.port") })
+	var port recorder
+	p.Watch("db.port", port.add)
+	p.AddValidator(func(p Provider) error {
+		if _, err := p.Int("db.port"); err != nil {
+			return errors.New("db.port should be a number")
+		}
+		return nil
+	})
+	if err := p.Set("db.port", "aaa"); err == nil {
+		t.Fatal("db.port should be a number")
+	}
+	if err := p.Delete("db.port"); err == nil {
+		t.Fatal("db.port shouldn't be deleted")
+	}
+	kv.Delete("/app/db/port")
+	waitFor(t, func() bool { return !p.Has("db.port") })
+	time.Sleep(10 * time.Millisecond)
+	if got := port.get(); !reflect.DeepEqual(got, []string{""}) {
+		t.Fatalf("db.port values are %v", got)
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// YAMLConfig parses the mapping subset of yaml:
+// top level scalars belong to default section, top level mappings are sections,
+// deeper mappings are flattened into keys joined by attributeDivision,
+// flow sequences like [a, b] are joined by ";".
+// Block sequences, block scalars and anchors are not supported.
+type YAMLConfig struct {
+}
+
+var yamlPlainKey = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.\-]*$`)
+
+// Parse parse yaml file
+func (y *YAMLConfig) Parse(fileName string) (Provider, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := y.ParseReader(f)
+	return withSource(p, err, fileName)
+}
+
+// ParseFS parse yaml file in fsys
+func (y *YAMLConfig) ParseFS(fsys fs.FS, name string) (Provider, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := y.ParseReader(f)
+	return withSource(p, err, name)
+}
+
+// ParseData parse yaml bytes data
+func (y *YAMLConfig) ParseData(data []byte) (Provider, error) {
+	return y.ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parse yaml data from reader line by line
+func (y *YAMLConfig) ParseReader(r io.Reader) (Provider, error) {
+	// level is an open mapping, it becomes an empty value when it ends without children like the null of yaml
+	type level struct {
+		indent   int
+		name     string
+		comment  string
+		children bool
+	}
+	var (
+		b       = new(documentBuilder)
+		stack   []level
+		comment bytes.Buffer
+		lineNum int
+	)
+	// path retrieves the section and key of the key in stack
+	path := func(stack []level, key string) (string, string) {
+		if len(stack) == 0 {
+			return defaultSection, key
+		}
+		names := make([]string, 0, len(stack))
+		for _, l := range stack[1:] {
+			names = append(names, l.name)
+		}
+		return stack[0].name, strings.Join(append(names, key), attributeDivision)
+	}
+	// child marks the top mapping has children, the top level mapping is a section
+	child := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := &stack[len(stack)-1]
+		if !top.children && len(stack) == 1 {
+			if s := b.section(top.name); top.comment != "" {
+				s.Comment = top.comment
+			}
+		}
+		top.children = true
+	}
+	// pop closes the mappings indented not less than indent
+	pop := func(indent int) {
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			if l := stack[len(stack)-1]; !l.children {
+				section, key := path(stack[:len(stack)-1], l.name)
+				b.set(section, key, "", l.comment)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	lines := newLineReader(r)
+	for {
+		text, err := lines.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		lineNum++
+		raw := strings.TrimRight(string(text), " \t\r")
+		line := strings.TrimLeft(raw, " ")
+		if line == "" || line == "---" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if comment.Len() > 0 {
+				comment.WriteByte('\n')
+			}
+			comment.WriteString(line[1:])
+			continue
+		}
+		if strings.HasPrefix(line, "\t") {
+			return nil, fmt.Errorf("yaml parse: line %d: tab indent is not allowed", lineNum)
+		}
+		if strings.HasPrefix(line, "- ") || line == "-" {
+			return nil, fmt.Errorf("yaml parse: line %d: block sequence is not supported", lineNum)
+		}
+		indent := len(raw) - len(line)
+		key, rest, err := splitYAMLKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("yaml parse: line %d: %v", lineNum, err)
+		}
+		pop(indent)
+		child()
+		key = strings.ToLower(key)
+		if rest == "" {
+			l := level{indent: indent, name: key}
+			if len(stack) == 0 {
+				l.comment = comment.String()
+				comment.Reset()
+			}
+			stack = append(stack, l)
+			continue
+		}
+		if rest == "{}" || strings.HasPrefix(rest, "{} #") {
+			// the empty mapping of top level is an empty section
+			if len(stack) == 0 {
+				if s := b.section(key); comment.Len() > 0 {
+					s.Comment = comment.String()
+				}
+				comment.Reset()
+			}
+			continue
+		}
+		value, err := parseYAMLValue(rest)
+		if err != nil {
+			return nil, fmt.Errorf("yaml parse: line %d: %v", lineNum, err)
+		}
+		section, key := path(stack, key)
+		b.set(section, key, value, comment.String())
+		comment.Reset()
+	}
+	pop(0)
+	return newContainerDocument(&b.doc), nil
+}
+
+// Marshal writes the document as yaml bytes data,
+// numbers and bools are written bare, other values are double quoted to keep them as strings.
+func (y *YAMLConfig) Marshal(doc *Document) ([]byte, error) {
+	if err := checkSectionConflict("yaml", doc); err != nil {
+		return nil, err
+	}
+	writeComment := func(buf *bytes.Buffer, indent, comment string) {
+		if comment == "" {
+			return
+		}
+		for _, line := range strings.Split(comment, lineBreak) {
+			buf.WriteString(indent + "#" + line + lineBreak)
+		}
+	}
+	key := func(k string) string {
+		if yamlPlainKey.MatchString(k) {
+			return k
+		}
+		return quoteString(k)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	// default section is written as top level keys
+	for _, section := range doc.Sections {
+		if section.Name != defaultSection {
+			continue
+		}
+		writeComment(buf, "", section.Comment)
+		for _, entry := range section.Entries {
+			writeComment(buf, "", entry.Comment)
+			buf.WriteString(key(entry.Key) + ": " + literalValue(entry.Value) + lineBreak)
+		}
+		buf.WriteString(lineBreak)
+	}
+	for _, section := range doc.Sections {
+		if section.Name == defaultSection {
+			continue
+		}
+		writeComment(buf, "", section.Comment)
+		if len(section.Entries) == 0 {
+			// "name:" without children is null
+			buf.WriteString(key(section.Name) + ": {}" + lineBreak + lineBreak)
+			continue
+		}
+		buf.WriteString(key(section.Name) + ":" + lineBreak)
+		for _, entry := range section.Entries {
+			writeComment(buf, "  ", entry.Comment)
+			buf.WriteString("  " + key(entry.Key) + ": " + literalValue(entry.Value) + lineBreak)
+		}
+		buf.WriteString(lineBreak)
+	}
+	return buf.Bytes(), nil
+}
+
+// splitYAMLKey splits line into key and the rest value
+func splitYAMLKey(line string) (key, rest string, err error) {
+	if line[0] == '"' || line[0] == '\'' {
+		end := closingQuote(line)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated key %s", line)
+		}
+		if key, err = unquoteYAML(line[:end+1]); err != nil {
+			return "", "", err
+		}
+		line = line[end+1:]
+		if !strings.HasPrefix(line, ":") {
+			return "", "", fmt.Errorf("missing ':' after key %s", key)
+		}
+		return key, strings.TrimSpace(line[1:]), nil
+	}
+	i := strings.Index(line, ": ")
+	if i < 0 {
+		if !strings.HasSuffix(line, ":") {
+			return "", "", fmt.Errorf("missing ':' in %s", line)
+		}
+		i = len(line) - 1
+	}
+	return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), nil
+}
+
+// parseYAMLValue parses quoted, plain and flow sequence values
+func parseYAMLValue(rest string) (string, error) {
+	switch rest[0] {
+	case '"', '\'':
+		end := closingQuote(rest)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated value %s", rest)
+		}
+		if tail := strings.TrimSpace(rest[end+1:]); tail != "" && !strings.HasPrefix(tail, "#") {
+			return "", fmt.Errorf("unexpected %s after value", tail)
+		}
+		return unquoteYAML(rest[:end+1])
+	case '[':
+		end := strings.LastIndex(rest, "]")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated sequence %s", rest)
+		}
+		flow, err := splitFlow(rest[1:end])
+		if err != nil {
+			return "", err
+		}
+		var items []string
+		for _, item := range flow {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			v, err := parseYAMLValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, v)
+		}
+		return strings.Join(items, ";"), nil
+	case '{', '|', '>', '&', '*', '!':
+		return "", fmt.Errorf("value %s is not supported", rest)
+	}
+	if i := strings.Index(rest, " #"); i >= 0 {
+		rest = strings.TrimSpace(rest[:i])
+	}
+	if rest == "~" || rest == "null" {
+		return "", nil
+	}
+	return rest, nil
+}
+
+// closingQuote retrieves the index of quote which closes s[0]
+func closingQuote(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// splitFlow splits the items of flow sequence by the commas out of quoted items
+func splitFlow(s string) ([]string, error) {
+	var items []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"', '\'':
+			// the quote in the middle of plain item is literal, like don't
+			if strings.TrimSpace(s[start:i]) != "" {
+				continue
+			}
+			end := closingQuote(s[i:])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated value %s", s[i:])
+			}
+			i += end
+		case ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:]), nil
+}
+
+// unquoteYAML unquotes single or double quoted yaml string
+func unquoteYAML(s string) (string, error) {
+	if s[0] == '\'' {
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1), nil
+	}
+	return unquoteString(s)
+}
+
+// sniffYAML recognizes content starts with "---" or "key: value"
+func sniffYAML(data []byte) bool {
+	line := string(firstLine(data, "#"))
+	if line == "" || line == "---" {
+		return line != ""
+	}
+	key, _, err := splitYAMLKey(line)
+	return err == nil && key != "" && !strings.ContainsAny(key, "=[")
+}
+
+func init() {
+	Register("yaml", &YAMLConfig{}, WithExtensions(".yaml", ".yml"), WithSniffer(sniffYAML))
+}
@@ -0,0 +1,157 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+
+// gen_gbk generates config_charset_gbk.go by iconv,
+// which holds the unicode of every two bytes gbk code,
+// and config_charset_gb18030.go, which holds the differences of gb18030 two bytes code
+// and the ranges of four bytes code in unicode BMP.
+// Run it by "go generate" in the config directory.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os/exec"
+	"unicode/utf8"
+)
+
+// decodeRune reads the only rune of iconv output line, U+FFFD for the undefined code
+func decodeRune(line []byte) rune {
+	r, n := utf8.DecodeRune(line)
+	if n != len(line) || r < 0x80 || r == 0xfeff {
+		return utf8.RuneError
+	}
+	return r
+}
+
+// iconv converts the lines of in, -c drops the undefined code, whose line is checked by callers
+func iconv(in []byte, from, to string) [][]byte {
+	cmd := exec.Command("iconv", "-c", "-f", from, "-t", to)
+	cmd.Stdin = bytes.NewReader(in)
+	out, err := cmd.Output()
+	if err != nil && len(out) == 0 {
+		log.Fatal(err)
+	}
+	return bytes.Split(bytes.TrimSuffix(out, []byte("\n")), []byte("\n"))
+}
+
+func writeSource(name string, buf *bytes.Buffer) {
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(name, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(name, "is generated")
+}
+
+func main() {
+	var in bytes.Buffer
+	for lead := 0x81; lead <= 0xfe; lead++ {
+		for trail := 0x40; trail <= 0xfe; trail++ {
+			if trail == 0x7f {
+				continue
+			}
+			in.Write([]byte{byte(lead), byte(trail), '\n'})
+		}
+	}
+	lines := iconv(in.Bytes(), "GBK", "UTF-8")
+	if len(lines) != 126*190 {
+		log.Fatalf("iconv returns %d codes, want %d", len(lines), 126*190)
+	}
+	gbk := make([]rune, len(lines))
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_gbk.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package config\n\n")
+	buf.WriteString("// gbkTable holds the unicode of gbk code, one row per lead byte from 0x81,\n")
+	buf.WriteString("// and one rune per trail byte from 0x40 to 0xfe except 0x7f, U+FFFD is undefined.\n")
+	buf.WriteString("const gbkTable = \"\" +\n")
+	for i, line := range lines {
+		if i%190 == 0 {
+			buf.WriteString("\t\"")
+		}
+		gbk[i] = decodeRune(line)
+		buf.WriteRune(gbk[i])
+		if i%190 == 189 {
+			if i == len(lines)-1 {
+				buf.WriteString("\"\n")
+			} else {
+				buf.WriteString("\" +\n")
+			}
+		}
+	}
+	writeSource("config_charset_gbk.go", &buf)
+
+	// the two bytes code of gb18030 differing from gbk
+	lines = iconv(in.Bytes(), "GB18030", "UTF-8")
+	if len(lines) != 126*190 {
+		log.Fatalf("iconv returns %d codes, want %d", len(lines), 126*190)
+	}
+	buf.Reset()
+	buf.WriteString("// Code generated by gen_gbk.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package config\n\n")
+	buf.WriteString("// gb18030Diff holds the two bytes code of gb18030 differing from gbkTable, by the index of gbkTable\n")
+	buf.WriteString("var gb18030Diff = map[int]rune{\n")
+	for i, line := range lines {
+		if r := decodeRune(line); r != gbk[i] {
+			fmt.Fprintf(&buf, "%d: 0x%04x,\n", i, r)
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	// the four bytes code of BMP, whose linear index increases with unicode in ranges
+	in.Reset()
+	var runes []rune
+	for r := rune(0x80); r <= 0xffff; r++ {
+		if r >= 0xd800 && r <= 0xdfff {
+			continue
+		}
+		in.WriteString(string(r))
+		in.WriteByte('\n')
+		runes = append(runes, r)
+	}
+	lines = iconv(in.Bytes(), "UTF-8", "GB18030")
+	if len(lines) != len(runes) {
+		log.Fatalf("iconv returns %d codes, want %d", len(lines), len(runes))
+	}
+	buf.WriteString("// gb18030Ranges maps the four bytes code in BMP in the order of rune, the linear index of code is\n")
+	buf.WriteString("// (((b1-0x81)*10+b2-0x30)*126+b3-0x81)*10+b4-0x30, and increases with rune in each range of n runes.\n")
+	buf.WriteString("var gb18030Ranges = []gb18030Range{\n")
+	type fourRange struct{ r, index, n int }
+	var ranges []fourRange
+	for i, line := range lines {
+		if len(line) != 4 {
+			continue
+		}
+		index := (((int(line[0])-0x81)*10+int(line[1])-0x30)*126+int(line[2])-0x81)*10 + int(line[3]) - 0x30
+		r := int(runes[i])
+		if last := len(ranges) - 1; last >= 0 && r == ranges[last].r+ranges[last].n && index == ranges[last].index+ranges[last].n {
+			ranges[last].n++
+			continue
+		}
+		ranges = append(ranges, fourRange{r, index, 1})
+	}
+	for _, fr := range ranges {
+		fmt.Fprintf(&buf, "{0x%04x, %d, %d},\n", fr.r, fr.index, fr.n)
+	}
+	buf.WriteString("}\n")
+	writeSource("config_charset_gb18030.go", &buf)
+}
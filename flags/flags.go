@@ -0,0 +1,258 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flags provides feature flags declared in the [features] section of config.
+//
+// A flag is declared in short form:
+//
+//	[features]
+//	new_checkout = on      ; on or off
+//	dark_mode = 25%        ; enabled for 25% of subjects
+//
+// or by attributes:
+//
+//	[features]
+//	beta.enabled = on                     ; off disables the flag whatever the other attributes are
+//	beta.rollout = 10                     ; percent of subjects, 100 by default, 0 when allow is set
+//	beta.allow = user_1,user_2            ; subjects always enabled, split by ","
+//	beta.from = 2026-11-01                ; enabled from the time
+//	beta.until = 2026-12-01T00:00:00Z     ; enabled until the time
+//
+// Usage:
+//
+//	import "github.com/Tobecoder/readygo/flags"
+//
+//	if flags.Enabled("new_checkout", userID) {
+//		//todo
+//	}
+//
+// The subject, such as session id or user id, is bucketed by hash of flag name and subject,
+// so a subject keeps the same result when the rollout doesn't change.
+// Flags are read from the provider on every call, so they apply live when config reloads.
+package flags
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tobecoder/readygo/config"
+)
+
+// Section is the config section declaring flags
+const Section = "features"
+
+// Flag is the declaration of a feature flag
+type Flag struct {
+	Name    string
+	Enabled bool
+	Rollout int // percent of subjects
+	Allow   []string
+	From    time.Time // zero means no start
+	Until   time.Time // zero means no end
+}
+
+// Flags evaluates the feature flags declared in provider
+type Flags struct {
+	provider func() config.Provider
+	now      func() time.Time
+}
+
+// New returns Flags declared in p
+func New(p config.Provider) *Flags {
+	return &Flags{
+		provider: func() config.Provider { return p },
+		now:      time.Now,
+	}
+}
+
+// std reads flags from the global config of default app
+var std = &Flags{provider: config.Global, now: time.Now}
+
+// SetDefault sets the Flags used by Enabled and Lookup
+func SetDefault(f *Flags) {
+	std = f
+}
+
+// Enabled reports whether the flag of name is enabled for subject by the default Flags
+func Enabled(name, subject string) bool {
+	return std.Enabled(name, subject)
+}
+
+// Lookup retrieves the flag of name by the default Flags
+func Lookup(name string) (Flag, error) {
+	return std.Lookup(name)
+}
+
+// Enabled reports whether the flag of name is enabled for subject,
+// undeclared and invalid flags are disabled.
+func (f *Flags) Enabled(name, subject string) bool {
+	flag, err := f.Lookup(name)
+	if err != nil {
+		return false
+	}
+	return flag.enabledAt(subject, f.now())
+}
+
+// Lookup retrieves the declaration of flag
+func (f *Flags) Lookup(name string) (Flag, error) {
+	name = strings.ToLower(name)
+	section, err := f.provider().GetSection(Section)
+	if err != nil {
+		return Flag{}, fmt.Errorf("flag %s not find", name)
+	}
+	return parseFlag(name, section)
+}
+
+// Names retrieves the sorted names of declared flags
+func (f *Flags) Names() []string {
+	section, err := f.provider().GetSection(Section)
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for key := range section {
+		name := key
+		if i := strings.IndexByte(key, '.'); i >= 0 {
+			name = key[:i]
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// enabledAt evaluates the flag for subject at now
+func (flag Flag) enabledAt(subject string, now time.Time) bool {
+	if !flag.Enabled {
+		return false
+	}
+	if !flag.From.IsZero() && now.Before(flag.From) {
+		return false
+	}
+	if !flag.Until.IsZero() && !now.Before(flag.Until) {
+		return false
+	}
+	for _, allowed := range flag.Allow {
+		if allowed == subject {
+			return true
+		}
+	}
+	if flag.Rollout >= 100 {
+		return true
+	}
+	if flag.Rollout <= 0 || subject == "" {
+		return false
+	}
+	return bucket(flag.Name, subject) < flag.Rollout
+}
+
+// bucket maps subject into [0, 100) sticky for the flag
+func bucket(name, subject string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(subject))
+	return int(h.Sum32() % 100)
+}
+
+// parseFlag parses the short form or attributes of flag in section
+func parseFlag(name string, section map[string]string) (Flag, error) {
+	flag := Flag{Name: name, Enabled: true, Rollout: -1}
+	declared := false
+	if value, ok := section[name]; ok {
+		declared = true
+		if err := flag.parseShort(value); err != nil {
+			return Flag{}, err
+		}
+	}
+	for key, value := range section {
+		attr := strings.TrimPrefix(key, name+".")
+		if attr == key {
+			continue
+		}
+		declared = true
+		value = strings.TrimSpace(value)
+		var err error
+		switch attr {
+		case "enabled":
+			flag.Enabled, err = config.ParseBool(value)
+		case "rollout":
+			flag.Rollout, err = parsePercent(value)
+		case "allow":
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					flag.Allow = append(flag.Allow, item)
+				}
+			}
+		case "from":
+			flag.From, err = config.ParseTime(value)
+		case "until":
+			flag.Until, err = config.ParseTime(value)
+		default:
+			err = fmt.Errorf("unknown attribute %s", attr)
+		}
+		if err != nil {
+			return Flag{}, fmt.Errorf("flag %s: %v", name, err)
+		}
+	}
+	if !declared {
+		return Flag{}, fmt.Errorf("flag %s not find", name)
+	}
+	if flag.Rollout < 0 {
+		flag.Rollout = 100
+		if len(flag.Allow) > 0 {
+			flag.Rollout = 0
+		}
+	}
+	return flag, nil
+}
+
+// parseShort parses "on", "off" or "25%"
+func (flag *Flag) parseShort(value string) error {
+	value = strings.TrimSpace(value)
+	if strings.HasSuffix(value, "%") {
+		rollout, err := parsePercent(value)
+		if err != nil {
+			return fmt.Errorf("flag %s: %v", flag.Name, err)
+		}
+		flag.Rollout = rollout
+		return nil
+	}
+	enabled, err := config.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("flag %s: %v", flag.Name, err)
+	}
+	flag.Enabled = enabled
+	return nil
+}
+
+// parsePercent parses "25" or "25%" in [0, 100]
+func parsePercent(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 || n > 100 {
+		return 0, fmt.Errorf("rollout %d isn't in [0, 100]", n)
+	}
+	return n, nil
+}
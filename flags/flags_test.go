@@ -0,0 +1,120 @@
+// Copyright readygo Author. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flags
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Tobecoder/readygo/config"
+)
+
+const featuresIni = `
+[features]
+new_checkout = on
+old_checkout = off
+dark_mode = 25%
+beta.rollout = 10
+beta.allow = alice, bob
+staff.allow = alice
+promo.from = 2026-11-01
+promo.until = 2026-12-01
+killed.enabled = off
+killed.rollout = 100
+broken = 200%
+`
+
+func TestFlags(t *testing.T) {
+	p, err := config.NewConfigData("ini", []byte(featuresIni))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := New(p)
+	f.now = func() time.Time { return time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC) }
+
+	if !f.Enabled("new_checkout", "") || !f.Enabled("NEW_CHECKOUT", "u1") {
+		t.Fatal("new_checkout should be on")
+	}
+	if f.Enabled("old_checkout", "u1") || f.Enabled("killed", "u1") {
+		t.Fatal("old_checkout and killed should be off")
+	}
+	if f.Enabled("aaa", "u1") || f.Enabled("broken", "u1") {
+		t.Fatal("undeclared and invalid flags should be off")
+	}
+	if _, err := f.Lookup("broken"); err == nil {
+		t.Fatal("200% isn't valid")
+	}
+
+	// test allow list
+	if !f.Enabled("staff", "alice") || f.Enabled("staff", "carol") {
+		t.Fatal("staff should be enabled for alice only")
+	}
+	if !f.Enabled("beta", "bob") {
+		t.Fatal("beta should be enabled for bob")
+	}
+
+	// test rollout is sticky and close to the percent
+	enabled := 0
+	for i := 0; i < 10000; i++ {
+		subject := fmt.Sprintf("user_%d", i)
+		result := f.Enabled("dark_mode", subject)
+		if result != f.Enabled("dark_mode", subject) {
+			t.Fatalf("%s isn't sticky", subject)
+		}
+		if result {
+			enabled++
+		}
+	}
+	if enabled < 2300 || enabled > 2700 {
+		t.Fatalf("dark_mode is enabled for %d of 10000", enabled)
+	}
+	if f.Enabled("dark_mode", "") {
+		t.Fatal("empty subject isn't in rollout")
+	}
+
+	// test time window
+	if !f.Enabled("promo", "u1") {
+		t.Fatal("promo should be enabled in November")
+	}
+	f.now = func() time.Time { return time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC) }
+	if f.Enabled("promo", "u1") {
+		t.Fatal("promo should be disabled from December")
+	}
+
+	// test changes apply live
+	p.Set("features.old_checkout", "on")
+	p.Set("features.staff.rollout", "100")
+	if !f.Enabled("old_checkout", "u1") || !f.Enabled("staff", "carol") {
+		t.Fatal("changes should apply live")
+	}
+
+	names := f.Names()
+	if fmt.Sprint(names) != "[beta broken dark_mode killed new_checkout old_checkout promo staff]" {
+		t.Fatalf("names are %v", names)
+	}
+	flag, err := f.Lookup("beta")
+	if err != nil || flag.Rollout != 10 || len(flag.Allow) != 2 || !flag.Enabled {
+		t.Fatalf("beta is %+v %v", flag, err)
+	}
+
+	// test default Flags reads global config
+	global := config.Global()
+	config.SetDefaultApp(config.NewApp(p))
+	defer config.SetDefaultApp(config.NewApp(global))
+	if !Enabled("new_checkout", "u1") {
+		t.Fatal("new_checkout should be on by default Flags")
+	}
+}